@@ -0,0 +1,277 @@
+package simplequery
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	InvalidNumberErr    = errors.New("The parameter value is not a valid number")
+	ExponentTooLargeErr = errors.New("The parameter value has too large an exponent")
+	TooManyDecimalsErr  = errors.New("The parameter value has too many decimal places")
+)
+
+const (
+	bigFloatPrecision = 64
+)
+
+// MaxBigExponent limits the magnitude of the exponent accepted by the
+// arbitrary-precision accessors, e.g. "1e5000" is rejected by default.
+// Converting such a value into an exact rational number would require
+// allocating all of its digits.
+var MaxBigExponent = 1000
+
+func (s *StringValue) ParseBigInt() (*big.Int, error) {
+	if s == nil {
+		return nil, UnspecifiedValueErr
+	}
+	str, base := DefaultIntSyntax.prepare(string(*s))
+	res, ok := new(big.Int).SetString(str, base)
+	if !ok {
+		return nil, InvalidNumberErr
+	}
+	return res, nil
+}
+
+// BigInt returns zero rather than nil if the value is missing or malformed
+// and no default is given.
+func (s *StringValue) BigInt(def ...*big.Int) *big.Int {
+	defVal := new(big.Int)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseBigInt(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+// ParseBigFloat parses a decimal floating-point number with 64 bits of
+// mantissa precision.
+func (s *StringValue) ParseBigFloat() (*big.Float, error) {
+	if s == nil {
+		return nil, UnspecifiedValueErr
+	}
+	str := string(*s)
+	if err := checkDecimalNumber(str, true, false); err != nil {
+		return nil, err
+	}
+	res, _, err := big.ParseFloat(str, 10, bigFloatPrecision, big.ToNearestEven)
+	if err != nil {
+		return nil, InvalidNumberErr
+	}
+	return res, nil
+}
+
+func (s *StringValue) BigFloat(def ...*big.Float) *big.Float {
+	defVal := new(big.Float)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseBigFloat(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+// ParseRat parses an exact rational number given either as a decimal number,
+// e.g. "12.345" or "1.5e3", or as a fraction, e.g. "1/3".
+func (s *StringValue) ParseRat() (*big.Rat, error) {
+	if s == nil {
+		return nil, UnspecifiedValueErr
+	}
+	str := string(*s)
+	if err := checkDecimalNumber(str, true, true); err != nil {
+		return nil, err
+	}
+	res, ok := new(big.Rat).SetString(str)
+	if !ok {
+		return nil, InvalidNumberErr
+	}
+	return res, nil
+}
+
+func (s *StringValue) Rat(def ...*big.Rat) *big.Rat {
+	defVal := new(big.Rat)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseRat(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+// Decimal is an exact fixed-point decimal number equal to
+// Unscaled * 10^-Scale, e.g. "12.345" is {12345, 3}.
+type Decimal struct {
+	Unscaled *big.Int
+	Scale    int
+}
+
+// ParseDecimal parses a number in plain decimal notation, e.g. "-12.345".
+// Exponents are not accepted. TooManyDecimalsErr is returned if the number
+// has more than maxScale digits after the decimal point.
+func ParseDecimal(str string, maxScale int) (Decimal, error) {
+	if err := checkDecimalNumber(str, false, false); err != nil {
+		return Decimal{}, err
+	}
+
+	scale := 0
+	if idx := strings.IndexByte(str, '.'); idx >= 0 {
+		scale = len(str) - idx - 1
+		str = str[:idx] + str[idx+1:]
+	}
+	if scale > maxScale {
+		return Decimal{}, TooManyDecimalsErr
+	}
+
+	unscaled, ok := new(big.Int).SetString(str, 10)
+	if !ok {
+		return Decimal{}, InvalidNumberErr
+	}
+	return Decimal{Unscaled: unscaled, Scale: scale}, nil
+}
+
+func (d Decimal) unscaled() *big.Int {
+	if d.Unscaled == nil {
+		return new(big.Int)
+	}
+	return d.Unscaled
+}
+
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.unscaled()).String()
+	if d.Scale > 0 {
+		if len(digits) <= d.Scale {
+			digits = strings.Repeat("0", d.Scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.Scale] + "." + digits[len(digits)-d.Scale:]
+	}
+	if d.unscaled().Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Rat returns the exact value of the decimal.
+func (d Decimal) Rat() *big.Rat {
+	denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.Scale)), nil)
+	return new(big.Rat).SetFrac(d.unscaled(), denom)
+}
+
+// Rescale returns the decimal with the given number of digits after the
+// decimal point. The boolean result reports whether the value was preserved
+// exactly; digits dropped when decreasing the scale are truncated.
+func (d Decimal) Rescale(scale int) (Decimal, bool) {
+	diff := scale - d.Scale
+	if diff >= 0 {
+		mul := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(diff)), nil)
+		return Decimal{new(big.Int).Mul(d.unscaled(), mul), scale}, true
+	}
+	div := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-diff)), nil)
+	quo, rem := new(big.Int).QuoRem(d.unscaled(), div, new(big.Int))
+	return Decimal{quo, scale}, rem.Sign() == 0
+}
+
+// Cmp compares d and other and returns -1, 0 or +1.
+func (d Decimal) Cmp(other Decimal) int {
+	return d.Rat().Cmp(other.Rat())
+}
+
+func (d Decimal) Sign() int {
+	return d.unscaled().Sign()
+}
+
+func (s *StringValue) ParseDecimal(maxScale int) (Decimal, error) {
+	if s == nil {
+		return Decimal{}, UnspecifiedValueErr
+	}
+	return ParseDecimal(string(*s), maxScale)
+}
+
+func (s *StringValue) Decimal(maxScale int, def ...Decimal) Decimal {
+	defVal := Decimal{}
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseDecimal(maxScale); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+// checkDecimalNumber makes sure str is a signed decimal number, optionally
+// followed by an exponent not exceeding MaxBigExponent in magnitude, or a
+// fraction of two decimal integers. Non-decimal forms accepted by math/big,
+// e.g. "0x1p-2" or "Inf", are rejected.
+func checkDecimalNumber(str string, allowExp, allowFrac bool) error {
+	if allowFrac {
+		if idx := strings.IndexByte(str, '/'); idx >= 0 {
+			if !isDecimalInt(str[:idx], true) || !isDecimalInt(str[idx+1:], false) {
+				return InvalidNumberErr
+			}
+			return nil
+		}
+	}
+
+	mantissa, exp := str, ""
+	if allowExp {
+		if idx := strings.IndexAny(str, "eE"); idx >= 0 {
+			mantissa, exp = str[:idx], str[idx+1:]
+			if !isDecimalInt(exp, true) {
+				return InvalidNumberErr
+			}
+		}
+	}
+
+	intPart, fracPart := mantissa, ""
+	if idx := strings.IndexByte(mantissa, '.'); idx >= 0 {
+		intPart, fracPart = mantissa[:idx], mantissa[idx+1:]
+		if fracPart != "" && !isDecimalInt(fracPart, false) {
+			return InvalidNumberErr
+		}
+	}
+	if intPart == "" || intPart == "-" || intPart == "+" {
+		if fracPart == "" {
+			return InvalidNumberErr
+		}
+	} else if !isDecimalInt(intPart, true) {
+		return InvalidNumberErr
+	}
+
+	if exp != "" {
+		// The digits are known to be valid, so an error means the exponent
+		// overflows int.
+		val, err := strconv.Atoi(exp)
+		if err != nil || val > MaxBigExponent || val < -MaxBigExponent {
+			return ExponentTooLargeErr
+		}
+	}
+	return nil
+}
+
+func isDecimalInt(str string, signed bool) bool {
+	if signed && len(str) > 0 && (str[0] == '-' || str[0] == '+') {
+		str = str[1:]
+	}
+	if str == "" {
+		return false
+	}
+	for i := range str {
+		if !isDigit(str[i]) {
+			return false
+		}
+	}
+	return true
+}
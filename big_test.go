@@ -0,0 +1,172 @@
+package simplequery
+
+import (
+	"math/big"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestStringValueBigInt(t *testing.T) {
+	RegisterTestingT(t)
+
+	var v *StringValue
+	var res *big.Int
+	var err error
+
+	v = psv("-340282366920938463463374607431768211456")
+
+	res, err = v.ParseBigInt()
+	Ω(err).Should(BeNil())
+	Ω(res.String()).Should(Equal("-340282366920938463463374607431768211456"))
+
+	v = psv("010")
+	Ω(v.BigInt().Int64()).Should(Equal(int64(10)))
+
+	v = psv("0x10")
+	_, err = v.ParseBigInt()
+	Ω(err).Should(Equal(InvalidNumberErr))
+	Ω(v.BigInt()).Should(Equal(new(big.Int)))
+	Ω(v.BigInt(big.NewInt(7))).Should(Equal(big.NewInt(7)))
+
+	v = nil
+	_, err = v.ParseBigInt()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	Ω(v.BigInt().Sign()).Should(Equal(0))
+}
+
+func TestStringValueBigFloat(t *testing.T) {
+	RegisterTestingT(t)
+
+	var v *StringValue
+	var res *big.Float
+	var err error
+
+	v = psv("12.5e-1")
+
+	res, err = v.ParseBigFloat()
+	Ω(err).Should(BeNil())
+	Ω(res.Text('f', 3)).Should(Equal("1.250"))
+
+	for _, str := range []string{"0x1p-2", "Inf", "1e1001", "1e", "", "1.2.3", "--1"} {
+		_, err = psv(str).ParseBigFloat()
+		Ω(err).ShouldNot(BeNil(), str)
+	}
+	_, err = psv("1e99999999999999999999").ParseBigFloat()
+	Ω(err).Should(Equal(ExponentTooLargeErr))
+
+	Ω(psv("x").BigFloat(big.NewFloat(2))).Should(Equal(big.NewFloat(2)))
+	Ω((*StringValue)(nil).BigFloat().Sign()).Should(Equal(0))
+}
+
+func TestStringValueRat(t *testing.T) {
+	RegisterTestingT(t)
+
+	var v *StringValue
+	var res *big.Rat
+	var err error
+
+	v = psv("12.345")
+
+	res, err = v.ParseRat()
+	Ω(err).Should(BeNil())
+	Ω(res.String()).Should(Equal("2469/200"))
+
+	res, err = psv("-1/3").ParseRat()
+	Ω(err).Should(BeNil())
+	Ω(res.String()).Should(Equal("-1/3"))
+
+	res, err = psv("1.5e3").ParseRat()
+	Ω(err).Should(BeNil())
+	Ω(res.String()).Should(Equal("1500/1"))
+
+	_, err = psv("1e1000000000").ParseRat()
+	Ω(err).Should(Equal(ExponentTooLargeErr))
+
+	_, err = psv("1e-1000000000").ParseRat()
+	Ω(err).Should(Equal(ExponentTooLargeErr))
+
+	for _, str := range []string{"1/0x3", "1/-3", "1/", "/3", "0x10", "1/3e5", ".", "e5"} {
+		_, err = psv(str).ParseRat()
+		Ω(err).ShouldNot(BeNil(), str)
+	}
+
+	_, err = psv("1/0").ParseRat()
+	Ω(err).Should(Equal(InvalidNumberErr))
+
+	Ω(psv("x").Rat(big.NewRat(1, 2))).Should(Equal(big.NewRat(1, 2)))
+	Ω((*StringValue)(nil).Rat().Sign()).Should(Equal(0))
+}
+
+func TestParseDecimal(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res Decimal
+	var err error
+
+	res, err = ParseDecimal("12.345", 4)
+	Ω(err).Should(BeNil())
+	Ω(res.Unscaled.String()).Should(Equal("12345"))
+	Ω(res.Scale).Should(Equal(3))
+	Ω(res.String()).Should(Equal("12.345"))
+	Ω(res.Rat().String()).Should(Equal("2469/200"))
+
+	res, err = ParseDecimal("-.05", 2)
+	Ω(err).Should(BeNil())
+	Ω(res.String()).Should(Equal("-0.05"))
+	Ω(res.Sign()).Should(Equal(-1))
+
+	res, err = ParseDecimal("+7", 2)
+	Ω(err).Should(BeNil())
+	Ω(res.String()).Should(Equal("7"))
+
+	_, err = ParseDecimal("12.345", 2)
+	Ω(err).Should(Equal(TooManyDecimalsErr))
+
+	for _, str := range []string{"1e3", "1/3", "", "-", ".", "1.2.3", "0x10", "1,5"} {
+		_, err = ParseDecimal(str, 2)
+		Ω(err).ShouldNot(BeNil(), str)
+	}
+}
+
+func TestDecimalRescale(t *testing.T) {
+	RegisterTestingT(t)
+
+	d, _ := ParseDecimal("12.345", 3)
+
+	res, exact := d.Rescale(5)
+	Ω(exact).Should(BeTrue())
+	Ω(res.String()).Should(Equal("12.34500"))
+	Ω(res.Cmp(d)).Should(Equal(0))
+
+	res, exact = d.Rescale(2)
+	Ω(exact).Should(BeFalse())
+	Ω(res.String()).Should(Equal("12.34"))
+	Ω(res.Cmp(d)).Should(Equal(-1))
+
+	res, exact = res.Rescale(1)
+	Ω(exact).Should(BeFalse())
+	Ω(res.String()).Should(Equal("12.3"))
+
+	Ω(Decimal{}.String()).Should(Equal("0"))
+	Ω(Decimal{}.Cmp(res)).Should(Equal(-1))
+}
+
+func TestStringValueDecimal(t *testing.T) {
+	RegisterTestingT(t)
+
+	var v *StringValue
+	var err error
+
+	def, _ := ParseDecimal("1.00", 2)
+
+	v = psv("9.99")
+	Ω(v.Decimal(2).String()).Should(Equal("9.99"))
+	Ω(v.Decimal(1, def).String()).Should(Equal("1.00"))
+
+	v = nil
+	_, err = v.ParseDecimal(2)
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	Ω(v.Decimal(2)).Should(Equal(Decimal{}))
+	Ω(v.Decimal(2, def)).Should(Equal(def))
+}
@@ -0,0 +1,165 @@
+package simplequery
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+var (
+	ValueTooLargeErr = errors.New("The parameter value is too large")
+)
+
+// MaxDecodedSize limits the number of bytes the binary accessors (Base64,
+// Base64URL, Base32 and Hex) decode from a single value. Longer values are
+// rejected with ValueTooLargeErr before any decoding takes place.
+var MaxDecodedSize = 64 << 10
+
+// ParseBase64 decodes standard base64 (RFC 4648), with or without padding.
+// Spaces are read as "+", since that is what an unescaped "+" in a query
+// string decodes to.
+func ParseBase64(str string) ([]byte, error) {
+	return decodeBase64(base64.RawStdEncoding, "base64", str, true)
+}
+
+// ParseBase64URL decodes URL-safe base64 (RFC 4648), with or without
+// padding.
+func ParseBase64URL(str string) ([]byte, error) {
+	return decodeBase64(base64.RawURLEncoding, "base64url", str, false)
+}
+
+func decodeBase64(enc *base64.Encoding, kind, str string, spaceAsPlus bool) ([]byte, error) {
+	raw := strings.TrimRight(str, "=")
+	if spaceAsPlus {
+		raw = strings.Replace(raw, " ", "+", -1)
+	}
+	if len(str)-len(raw) > 2 || (len(str) != len(raw) && len(str)%4 != 0) {
+		return nil, &ParseError{kind, str, errors.New("invalid padding")}
+	}
+	if enc.DecodedLen(len(raw)) > MaxDecodedSize {
+		return nil, ValueTooLargeErr
+	}
+	res, err := enc.DecodeString(raw)
+	if err != nil {
+		return nil, &ParseError{kind, str, err}
+	}
+	return res, nil
+}
+
+// ParseBase32 decodes standard base32 (RFC 4648), with or without padding.
+// Lower case letters are accepted.
+func ParseBase32(str string) ([]byte, error) {
+	raw := strings.ToUpper(strings.TrimRight(str, "="))
+	if len(str) != len(raw) && len(str)%8 != 0 {
+		return nil, &ParseError{"base32", str, errors.New("invalid padding")}
+	}
+	enc := base32.StdEncoding.WithPadding(base32.NoPadding)
+	if enc.DecodedLen(len(raw)) > MaxDecodedSize {
+		return nil, ValueTooLargeErr
+	}
+	// The unpadded decoder accepts trailing groups that cannot result from
+	// encoding whole bytes.
+	switch len(raw) % 8 {
+	case 1, 3, 6:
+		return nil, &ParseError{"base32", str, errors.New("invalid length")}
+	}
+	res, err := enc.DecodeString(raw)
+	if err != nil {
+		return nil, &ParseError{"base32", str, err}
+	}
+	return res, nil
+}
+
+// ParseHex decodes a hexadecimal string of either case.
+func ParseHex(str string) ([]byte, error) {
+	if hex.DecodedLen(len(str)) > MaxDecodedSize {
+		return nil, ValueTooLargeErr
+	}
+	res, err := hex.DecodeString(str)
+	if err != nil {
+		return nil, &ParseError{"hex", str, err}
+	}
+	return res, nil
+}
+
+func (s *StringValue) ParseBase64() ([]byte, error) {
+	if s == nil {
+		return nil, UnspecifiedValueErr
+	}
+	return ParseBase64(string(*s))
+}
+
+func (s *StringValue) Base64(def ...[]byte) []byte {
+	var defVal []byte
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseBase64(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseBase64URL() ([]byte, error) {
+	if s == nil {
+		return nil, UnspecifiedValueErr
+	}
+	return ParseBase64URL(string(*s))
+}
+
+func (s *StringValue) Base64URL(def ...[]byte) []byte {
+	var defVal []byte
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseBase64URL(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseBase32() ([]byte, error) {
+	if s == nil {
+		return nil, UnspecifiedValueErr
+	}
+	return ParseBase32(string(*s))
+}
+
+func (s *StringValue) Base32(def ...[]byte) []byte {
+	var defVal []byte
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseBase32(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseHex() ([]byte, error) {
+	if s == nil {
+		return nil, UnspecifiedValueErr
+	}
+	return ParseHex(string(*s))
+}
+
+func (s *StringValue) Hex(def ...[]byte) []byte {
+	var defVal []byte
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseHex(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
@@ -0,0 +1,147 @@
+package simplequery
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseBase64(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res []byte
+	var err error
+
+	for _, str := range []string{"+/8=", "+/8", " /8"} {
+		res, err = ParseBase64(str)
+		Ω(err).Should(BeNil(), str)
+		Ω(res).Should(Equal([]byte{0xfb, 0xff}), str)
+	}
+
+	res, err = ParseBase64("")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(BeEmpty())
+
+	for _, str := range []string{"-_8", "+/8==", "+/8===", "+", "+/8=a", "a=b"} {
+		_, err = ParseBase64(str)
+		Ω(err).ShouldNot(BeNil(), str)
+		Ω(err.(*ParseError).Kind).Should(Equal("base64"))
+		Ω(err.(*ParseError).Value).Should(Equal(str))
+	}
+}
+
+func TestParseBase64URL(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res []byte
+	var err error
+
+	for _, str := range []string{"-_8=", "-_8"} {
+		res, err = ParseBase64URL(str)
+		Ω(err).Should(BeNil(), str)
+		Ω(res).Should(Equal([]byte{0xfb, 0xff}), str)
+	}
+
+	for _, str := range []string{"+/8", " _8", "-_8=="} {
+		_, err = ParseBase64URL(str)
+		Ω(err).ShouldNot(BeNil(), str)
+		Ω(err.(*ParseError).Kind).Should(Equal("base64url"))
+	}
+}
+
+func TestParseBase32(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res []byte
+	var err error
+
+	for _, str := range []string{"MZXW6===", "MZXW6", "mzxw6"} {
+		res, err = ParseBase32(str)
+		Ω(err).Should(BeNil(), str)
+		Ω(res).Should(Equal([]byte("foo")), str)
+	}
+
+	for _, str := range []string{"MZXW6=", "MZXW1", "M", "MZX", "MZXW6A"} {
+		_, err = ParseBase32(str)
+		Ω(err).ShouldNot(BeNil(), str)
+		Ω(err.(*ParseError).Kind).Should(Equal("base32"))
+	}
+}
+
+func TestParseHex(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res []byte
+	var err error
+
+	res, err = ParseHex("DEADbeef")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal([]byte{0xde, 0xad, 0xbe, 0xef}))
+
+	for _, str := range []string{"abc", "0x00", "zz"} {
+		_, err = ParseHex(str)
+		Ω(err).ShouldNot(BeNil(), str)
+		Ω(err.(*ParseError).Kind).Should(Equal("hex"))
+	}
+}
+
+func TestMaxDecodedSize(t *testing.T) {
+	RegisterTestingT(t)
+
+	defer func(size int) { MaxDecodedSize = size }(MaxDecodedSize)
+	MaxDecodedSize = 4
+
+	var err error
+
+	_, err = ParseHex("0011223344")
+	Ω(err).Should(Equal(ValueTooLargeErr))
+	_, err = ParseHex("00112233")
+	Ω(err).Should(BeNil())
+
+	_, err = ParseBase64("AAAAAAA")
+	Ω(err).Should(Equal(ValueTooLargeErr))
+	_, err = ParseBase64("AAAAAA==")
+	Ω(err).Should(BeNil())
+
+	_, err = ParseBase64URL(strings.Repeat("A", 1<<20))
+	Ω(err).Should(Equal(ValueTooLargeErr))
+
+	_, err = ParseBase32("AAAAAAAAA")
+	Ω(err).Should(Equal(ValueTooLargeErr))
+	_, err = ParseBase32("AAAAAAA=")
+	Ω(err).Should(BeNil())
+}
+
+func TestStringValueBinary(t *testing.T) {
+	RegisterTestingT(t)
+
+	var v *StringValue
+	var err error
+
+	def := []byte("def")
+
+	v = psv("Zm9v")
+	Ω(v.Base64()).Should(Equal([]byte("foo")))
+	Ω(v.Base64URL()).Should(Equal([]byte("foo")))
+	Ω(v.Hex(def)).Should(Equal(def))
+	Ω(v.Base32(def)).Should(Equal(def))
+
+	v = psv("666f6f")
+	Ω(v.Hex()).Should(Equal([]byte("foo")))
+
+	v = psv("MZXW6")
+	Ω(v.Base32()).Should(Equal([]byte("foo")))
+
+	v = nil
+	_, err = v.ParseBase64()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	_, err = v.ParseBase64URL()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	_, err = v.ParseBase32()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	_, err = v.ParseHex()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	Ω(v.Base64()).Should(BeNil())
+	Ω(v.Hex(def)).Should(Equal(def))
+}
@@ -0,0 +1,139 @@
+package simplequery
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Set replaces the values of key with value.
+func (q Q) Set(key, value string) {
+	q[key] = ValueSet{StringValue(value)}
+}
+
+// Add appends value to the values of key.
+func (q Q) Add(key, value string) {
+	q[key] = append(q[key], StringValue(value))
+}
+
+func (q Q) Del(key string) {
+	delete(q, key)
+}
+
+// Clone returns a copy of q that does not share any values with it.
+func (q Q) Clone() Q {
+	res := make(Q, len(q))
+	for k, vs := range q {
+		res[k] = append(ValueSet{}, vs...)
+	}
+	return res
+}
+
+// Without returns a copy of q without the given keys.
+func (q Q) Without(keys ...string) Q {
+	res := q.Clone()
+	for _, key := range keys {
+		delete(res, key)
+	}
+	return res
+}
+
+// Merge adds the values of other to q. The values of the keys present in
+// both are reduced by policy, applied to the values of q followed by the
+// values of other, e.g. LastValue makes other take precedence and
+// SingleValue rejects such keys. A nil policy keeps all the values. If the
+// policy fails, q is left unchanged.
+func (q Q) Merge(other Q, policy Policy) error {
+	res := make(Q, len(other))
+	for k, vs := range other {
+		cur, ok := q[k]
+		if !ok {
+			res[k] = append(ValueSet{}, vs...)
+			continue
+		}
+
+		all := append(append(ValueSet{}, cur...), vs...)
+		if policy == nil {
+			res[k] = all
+			continue
+		}
+		val, err := policy(all)
+		if err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+		if val == nil {
+			res[k] = ValueSet{}
+		} else {
+			res[k] = ValueSet{*val}
+		}
+	}
+
+	for k, vs := range res {
+		q[k] = vs
+	}
+	return nil
+}
+
+// Values converts q to url.Values, e.g. to build a URL with Encode.
+func (q Q) Values() url.Values {
+	res := make(url.Values, len(q))
+	for k, vs := range q {
+		res[k] = vs.Strings()
+	}
+	return res
+}
+
+func (q Q) SetBool(key string, val bool) {
+	q.Set(key, strconv.FormatBool(val))
+}
+
+func (q Q) SetInt64(key string, val int64) {
+	q.Set(key, strconv.FormatInt(val, 10))
+}
+
+func (q Q) SetUint64(key string, val uint64) {
+	q.Set(key, strconv.FormatUint(val, 10))
+}
+
+func (q Q) SetFloat64(key string, val float64) {
+	q.Set(key, strconv.FormatFloat(val, 'g', -1, 64))
+}
+
+// TimeFormat selects how SetTime formats times. StringValue.Time reads an
+// integer as seconds since the Unix epoch up to 4102444800 (2100-01-01) and
+// as milliseconds above it, so the Unix formats round-trip only within the
+// ranges given below; outside of them they are meant for outgoing URLs only.
+type TimeFormat int
+
+const (
+	// RFC3339Time formats times as RFC 3339 with sub-second precision added
+	// if present, e.g. "2016-02-03T04:05:06Z". It round-trips for the years
+	// 0 to 9999.
+	RFC3339Time TimeFormat = iota
+	// UnixTime formats times as seconds since the Unix epoch, dropping the
+	// sub-second part. It round-trips for times up to 2100-01-01T00:00:00Z,
+	// including the ones before 1970.
+	UnixTime
+	// UnixMilliTime formats times as milliseconds since the Unix epoch. It
+	// round-trips for times from 1970-02-17T11:34:04.801Z on.
+	UnixMilliTime
+)
+
+// SetTime sets key to val formatted according to format, RFC3339Time by
+// default.
+func (q Q) SetTime(key string, val time.Time, format ...TimeFormat) {
+	f := RFC3339Time
+	if len(format) > 0 {
+		f = format[0]
+	}
+
+	switch f {
+	case UnixTime:
+		q.SetInt64(key, val.Unix())
+	case UnixMilliTime:
+		q.SetInt64(key, val.UnixMilli())
+	default:
+		q.Set(key, val.Format(time.RFC3339Nano))
+	}
+}
@@ -0,0 +1,155 @@
+package simplequery
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestQBuilder(t *testing.T) {
+	RegisterTestingT(t)
+
+	q := NewQ()
+	q.Set("a", "1")
+	q.Add("a", "2")
+	q.Add("b", "x")
+	q.Set("c", "y")
+	q.Set("c", "z")
+	Ω(q).Should(Equal(Q{
+		"a": ValueSetFrom([]string{"1", "2"}),
+		"b": ValueSetFrom([]string{"x"}),
+		"c": ValueSetFrom([]string{"z"}),
+	}))
+
+	q.Del("b")
+	q.Del("missing")
+	Ω(q.Has("b")).Should(BeFalse())
+
+	c := q.Clone()
+	Ω(c).Should(Equal(q))
+	c.Add("a", "3")
+	c["c"][0] = "changed"
+	Ω(q.GetAll("a").Strings()).Should(Equal([]string{"1", "2"}))
+	Ω(q.Get("c").String()).Should(Equal("z"))
+
+	w := q.Without("a", "missing")
+	Ω(w).Should(Equal(Q{"c": ValueSetFrom([]string{"z"})}))
+	Ω(q.Has("a")).Should(BeTrue())
+
+	Ω(q.Values()).Should(Equal(url.Values{"a": {"1", "2"}, "c": {"z"}}))
+	Ω(FromQuery(q.Values())).Should(Equal(q))
+}
+
+func TestQMerge(t *testing.T) {
+	RegisterTestingT(t)
+
+	base := Q{
+		"a": ValueSetFrom([]string{"1"}),
+		"b": ValueSetFrom([]string{"x"}),
+	}
+	other := Q{
+		"a": ValueSetFrom([]string{"2", "3"}),
+		"c": ValueSetFrom([]string{"y"}),
+	}
+
+	var q Q
+	var err error
+
+	q = base.Clone()
+	Ω(q.Merge(other, nil)).Should(Succeed())
+	Ω(q.GetAll("a").Strings()).Should(Equal([]string{"1", "2", "3"}))
+	Ω(q.GetAll("b").Strings()).Should(Equal([]string{"x"}))
+	Ω(q.GetAll("c").Strings()).Should(Equal([]string{"y"}))
+
+	q = base.Clone()
+	Ω(q.Merge(other, FirstValue)).Should(Succeed())
+	Ω(q.GetAll("a").Strings()).Should(Equal([]string{"1"}))
+
+	q = base.Clone()
+	Ω(q.Merge(other, LastValue)).Should(Succeed())
+	Ω(q.GetAll("a").Strings()).Should(Equal([]string{"3"}))
+
+	q = base.Clone()
+	Ω(q.Merge(other, JoinValues(","))).Should(Succeed())
+	Ω(q.GetAll("a").Strings()).Should(Equal([]string{"1,2,3"}))
+
+	q = base.Clone()
+	err = q.Merge(other, SingleValue)
+	Ω(errors.Is(err, DuplicateValueErr)).Should(BeTrue())
+	Ω(q).Should(Equal(base))
+
+	// Merged values are not shared with other.
+	q = base.Clone()
+	Ω(q.Merge(other, nil)).Should(Succeed())
+	q["c"][0] = "changed"
+	Ω(other.Get("c").String()).Should(Equal("y"))
+}
+
+func TestQTypedSetters(t *testing.T) {
+	RegisterTestingT(t)
+
+	at := time.Date(2016, 2, 3, 4, 5, 6, 7000000, time.UTC)
+
+	q := NewQ()
+	q.SetBool("bool", true)
+	q.SetInt64("int", -12)
+	q.SetUint64("uint", 12)
+	q.SetFloat64("float", 1.5)
+	q.SetTime("time", at)
+	q.SetTime("sec", at, UnixTime)
+	q.SetTime("ms", at, UnixMilliTime)
+
+	Ω(q.Values()).Should(Equal(url.Values{
+		"bool":  {"true"},
+		"int":   {"-12"},
+		"uint":  {"12"},
+		"float": {"1.5"},
+		"time":  {"2016-02-03T04:05:06.007Z"},
+		"sec":   {"1454472306"},
+		"ms":    {"1454472306007"},
+	}))
+
+	Ω(q.Get("bool").Bool()).Should(BeTrue())
+	Ω(q.Get("int").Int64()).Should(Equal(int64(-12)))
+	Ω(q.Get("float").Float64()).Should(Equal(1.5))
+	Ω(q.Get("time").Time()).Should(Equal(at))
+	Ω(q.Get("sec").Time()).Should(Equal(at.Truncate(time.Second)))
+	Ω(q.Get("ms").Time()).Should(Equal(at))
+}
+
+func TestQSetTime_Ranges(t *testing.T) {
+	RegisterTestingT(t)
+
+	roundTrip := func(at time.Time, f TimeFormat) time.Time {
+		q := NewQ()
+		q.SetTime("t", at, f)
+		return q.Get("t").Time()
+	}
+
+	maxUnix := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+	Ω(roundTrip(maxUnix, UnixTime)).Should(Equal(maxUnix))
+	Ω(roundTrip(maxUnix.Add(time.Second), UnixTime)).ShouldNot(Equal(maxUnix.Add(time.Second)))
+	before1970 := time.Date(1960, 5, 6, 7, 8, 9, 0, time.UTC)
+	Ω(roundTrip(before1970, UnixTime)).Should(Equal(before1970))
+
+	minMilli := time.UnixMilli(4102444801).UTC()
+	Ω(minMilli).Should(Equal(time.Date(1970, 2, 17, 11, 34, 4, 801000000, time.UTC)))
+	Ω(roundTrip(minMilli, UnixMilliTime)).Should(Equal(minMilli))
+	Ω(roundTrip(minMilli.Add(-time.Millisecond), UnixMilliTime)).ShouldNot(Equal(minMilli.Add(-time.Millisecond)))
+	Ω(roundTrip(before1970, UnixMilliTime)).ShouldNot(Equal(before1970))
+	late := time.Date(2150, 1, 1, 0, 0, 0, 0, time.UTC)
+	Ω(roundTrip(late, UnixMilliTime)).Should(Equal(late))
+	Ω(roundTrip(late, UnixTime)).ShouldNot(Equal(late))
+
+	for _, at := range []time.Time{
+		time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+		before1970,
+		late,
+		time.Date(9999, 12, 31, 23, 59, 59, 999999999, time.UTC),
+	} {
+		Ω(roundTrip(at, RFC3339Time)).Should(Equal(at))
+	}
+}
@@ -0,0 +1,361 @@
+package simplequery
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	InvalidRangeErr = errors.New("The range end precedes its start")
+)
+
+const (
+	dateLayout = "2006-01-02"
+	// Range bounds are separated as in ISO 8601 time intervals,
+	// e.g. "2016-02-01/2016-02-29".
+	rangeSeparator = "/"
+)
+
+// Date is a calendar date with no time zone attached. Unlike time.Time it
+// does not denote an instant, so it does not shift when the value is
+// interpreted in a different location.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date on which t occurs in t's location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// ParseDate parses a date in "YYYY-MM-DD" format.
+func ParseDate(str string) (Date, error) {
+	t, err := time.Parse(dateLayout, str)
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
+}
+
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// In returns the time of the midnight starting the date in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// At returns the time corresponding to tod on the date in loc.
+func (d Date) At(tod TimeOfDay, loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day,
+		tod.Hour, tod.Minute, tod.Second, tod.Nanosecond, loc)
+}
+
+// AddDays returns the date n days after d (before d if n is negative).
+func (d Date) AddDays(n int) Date {
+	return DateOf(time.Date(d.Year, d.Month, d.Day+n, 0, 0, 0, 0, time.UTC))
+}
+
+func (d Date) Before(other Date) bool {
+	return d.Compare(other) < 0
+}
+
+func (d Date) After(other Date) bool {
+	return d.Compare(other) > 0
+}
+
+// Compare returns -1, 0 or +1 depending on whether d is before, equal to or
+// after other.
+func (d Date) Compare(other Date) int {
+	switch {
+	case d.Year != other.Year:
+		return cmpInt(d.Year, other.Year)
+	case d.Month != other.Month:
+		return cmpInt(int(d.Month), int(other.Month))
+	default:
+		return cmpInt(d.Day, other.Day)
+	}
+}
+
+// TimeOfDay is a wall clock time with no date or time zone attached.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// ParseTimeOfDay parses a time of day in "HH:MM", "HH:MM:SS" or
+// "HH:MM:SS.fffffffff" format.
+func ParseTimeOfDay(str string) (TimeOfDay, error) {
+	layout := "15:04"
+	if strings.Count(str, ":") > 1 {
+		layout = "15:04:05"
+	}
+	t, err := time.Parse(layout, str)
+	if err != nil {
+		return TimeOfDay{}, err
+	}
+	return TimeOfDay{
+		Hour:       t.Hour(),
+		Minute:     t.Minute(),
+		Second:     t.Second(),
+		Nanosecond: t.Nanosecond(),
+	}, nil
+}
+
+func (t TimeOfDay) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond != 0 {
+		s += strings.TrimRight(fmt.Sprintf(".%09d", t.Nanosecond), "0")
+	}
+	return s
+}
+
+// On returns the time corresponding to t on the given date in loc.
+func (t TimeOfDay) On(d Date, loc *time.Location) time.Time {
+	return d.At(t, loc)
+}
+
+// Compare returns -1, 0 or +1 depending on whether t is before, equal to or
+// after other.
+func (t TimeOfDay) Compare(other TimeOfDay) int {
+	return cmpInt(t.sinceMidnight(), other.sinceMidnight())
+}
+
+func (t TimeOfDay) sinceMidnight() int {
+	return ((t.Hour*60+t.Minute)*60+t.Second)*int(time.Second) + t.Nanosecond
+}
+
+// DateRange is an inclusive range of calendar dates.
+type DateRange struct {
+	Start Date
+	End   Date
+}
+
+// ParseDateRange parses a range of dates in "START/END" format, both ends
+// inclusive.
+func ParseDateRange(str string) (DateRange, error) {
+	parts := strings.SplitN(str, rangeSeparator, 2)
+	if len(parts) != 2 {
+		return DateRange{}, fmt.Errorf("parsing date range %q: missing %q",
+			str, rangeSeparator)
+	}
+
+	start, err := ParseDate(parts[0])
+	if err != nil {
+		return DateRange{}, err
+	}
+	end, err := ParseDate(parts[1])
+	if err != nil {
+		return DateRange{}, err
+	}
+	if end.Before(start) {
+		return DateRange{}, InvalidRangeErr
+	}
+	return DateRange{Start: start, End: end}, nil
+}
+
+func (r DateRange) String() string {
+	return r.Start.String() + rangeSeparator + r.End.String()
+}
+
+func (r DateRange) Contains(d Date) bool {
+	return !d.Before(r.Start) && !d.After(r.End)
+}
+
+// Days returns the number of days in the range.
+func (r DateRange) Days() int {
+	start := time.Date(r.Start.Year, r.Start.Month, r.Start.Day, 0, 0, 0, 0, time.UTC)
+	end := time.Date(r.End.Year, r.End.Month, r.End.Day, 0, 0, 0, 0, time.UTC)
+	// Not end.Sub(start), which saturates at about 292 years.
+	return int((end.Unix()-start.Unix())/(24*60*60)) + 1
+}
+
+// ForEach calls fn for every date in the range, in order.
+func (r DateRange) ForEach(fn func(Date)) {
+	for d := r.Start; !d.After(r.End); d = d.AddDays(1) {
+		fn(d)
+	}
+}
+
+// In returns the half-open interval [start, end) of instants covered by the
+// range in loc: from the midnight starting the first day up to the midnight
+// ending the last one.
+func (r DateRange) In(loc *time.Location) (time.Time, time.Time) {
+	return r.Start.In(loc), r.End.AddDays(1).In(loc)
+}
+
+// TimeOfDayRange is a range of wall clock times. The range wraps around
+// midnight when End is before Start, e.g. "22:00/06:00".
+type TimeOfDayRange struct {
+	Start TimeOfDay
+	End   TimeOfDay
+}
+
+// ParseTimeOfDayRange parses a range of times of day in "START/END" format.
+func ParseTimeOfDayRange(str string) (TimeOfDayRange, error) {
+	parts := strings.SplitN(str, rangeSeparator, 2)
+	if len(parts) != 2 {
+		return TimeOfDayRange{}, fmt.Errorf("parsing time of day range %q: missing %q",
+			str, rangeSeparator)
+	}
+
+	start, err := ParseTimeOfDay(parts[0])
+	if err != nil {
+		return TimeOfDayRange{}, err
+	}
+	end, err := ParseTimeOfDay(parts[1])
+	if err != nil {
+		return TimeOfDayRange{}, err
+	}
+	return TimeOfDayRange{Start: start, End: end}, nil
+}
+
+func (r TimeOfDayRange) String() string {
+	return r.Start.String() + rangeSeparator + r.End.String()
+}
+
+// Contains reports whether t falls within the range. The start is inclusive,
+// the end is exclusive.
+func (r TimeOfDayRange) Contains(t TimeOfDay) bool {
+	if r.Start.Compare(r.End) <= 0 {
+		return r.Start.Compare(t) <= 0 && t.Compare(r.End) < 0
+	}
+	return r.Start.Compare(t) <= 0 || t.Compare(r.End) < 0
+}
+
+// On returns the half-open interval [start, end) of instants covered by the
+// range on the given date in loc. If the range wraps around midnight the end
+// falls on the following day.
+func (r TimeOfDayRange) On(d Date, loc *time.Location) (time.Time, time.Time) {
+	endDate := d
+	if r.End.Compare(r.Start) < 0 {
+		endDate = d.AddDays(1)
+	}
+	return d.At(r.Start, loc), endDate.At(r.End, loc)
+}
+
+func (s *StringValue) ParseDate() (Date, error) {
+	if s == nil {
+		return Date{}, UnspecifiedValueErr
+	}
+	return ParseDate(string(*s))
+}
+
+func (s *StringValue) Date(def ...Date) Date {
+	defVal := Date{}
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseDate(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseTimeOfDay() (TimeOfDay, error) {
+	if s == nil {
+		return TimeOfDay{}, UnspecifiedValueErr
+	}
+	return ParseTimeOfDay(string(*s))
+}
+
+func (s *StringValue) TimeOfDay(def ...TimeOfDay) TimeOfDay {
+	defVal := TimeOfDay{}
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseTimeOfDay(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseDateRange() (DateRange, error) {
+	if s == nil {
+		return DateRange{}, UnspecifiedValueErr
+	}
+	return ParseDateRange(string(*s))
+}
+
+func (s *StringValue) DateRange(def ...DateRange) DateRange {
+	defVal := DateRange{}
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseDateRange(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseTimeOfDayRange() (TimeOfDayRange, error) {
+	if s == nil {
+		return TimeOfDayRange{}, UnspecifiedValueErr
+	}
+	return ParseTimeOfDayRange(string(*s))
+}
+
+func (s *StringValue) TimeOfDayRange(def ...TimeOfDayRange) TimeOfDayRange {
+	defVal := TimeOfDayRange{}
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseTimeOfDayRange(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s ValueSet) Dates() []Date {
+	res := make([]Date, len(s))
+	for i := range s {
+		res[i] = s[i].Date()
+	}
+	return res
+}
+
+func (s ValueSet) ParseDates(mode ...PluralMode) ([]Date, error) {
+	return parseValues(s, (*StringValue).ParseDate, mode...)
+}
+
+func (s ValueSet) TimesOfDay() []TimeOfDay {
+	res := make([]TimeOfDay, len(s))
+	for i := range s {
+		res[i] = s[i].TimeOfDay()
+	}
+	return res
+}
+
+func (s ValueSet) ParseTimesOfDay(mode ...PluralMode) ([]TimeOfDay, error) {
+	return parseValues(s, (*StringValue).ParseTimeOfDay, mode...)
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
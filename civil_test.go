@@ -0,0 +1,304 @@
+package simplequery
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseDate(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res Date
+	var err error
+
+	res, err = ParseDate("2016-02-29")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(Date{2016, time.February, 29}))
+	Ω(res.String()).Should(Equal("2016-02-29"))
+
+	_, err = ParseDate("2015-02-29")
+	Ω(err).ShouldNot(BeNil())
+
+	_, err = ParseDate("2016-02-03T15:04:05Z")
+	Ω(err).ShouldNot(BeNil())
+
+	_, err = ParseDate("")
+	Ω(err).ShouldNot(BeNil())
+}
+
+func TestDateIn(t *testing.T) {
+	RegisterTestingT(t)
+
+	loc := time.FixedZone("EST", -5*60*60)
+	d := Date{2016, time.February, 3}
+
+	Ω(d.In(loc)).Should(Equal(time.Date(2016, 2, 3, 0, 0, 0, 0, loc)))
+	Ω(DateOf(d.In(loc))).Should(Equal(d))
+	Ω(d.At(TimeOfDay{Hour: 23, Minute: 30}, loc)).Should(Equal(
+		time.Date(2016, 2, 3, 23, 30, 0, 0, loc)))
+	Ω(DateOf(d.At(TimeOfDay{Hour: 23, Minute: 30}, loc))).Should(Equal(d))
+}
+
+func TestDateAddDays(t *testing.T) {
+	RegisterTestingT(t)
+
+	d := Date{2016, time.February, 28}
+
+	Ω(d.AddDays(1)).Should(Equal(Date{2016, time.February, 29}))
+	Ω(d.AddDays(2)).Should(Equal(Date{2016, time.March, 1}))
+	Ω(d.AddDays(-28)).Should(Equal(Date{2016, time.January, 31}))
+	Ω(d.AddDays(0)).Should(Equal(d))
+}
+
+func TestDateCompare(t *testing.T) {
+	RegisterTestingT(t)
+
+	d := Date{2016, time.February, 28}
+
+	Ω(d.Compare(d)).Should(Equal(0))
+	Ω(d.Compare(Date{2016, time.February, 29})).Should(Equal(-1))
+	Ω(d.Compare(Date{2016, time.January, 29})).Should(Equal(1))
+	Ω(d.Compare(Date{2015, time.December, 31})).Should(Equal(1))
+	Ω(d.Before(Date{2017, time.January, 1})).Should(BeTrue())
+	Ω(d.After(Date{2017, time.January, 1})).Should(BeFalse())
+}
+
+func TestParseTimeOfDay(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res TimeOfDay
+	var err error
+
+	res, err = ParseTimeOfDay("09:30")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(TimeOfDay{Hour: 9, Minute: 30}))
+	Ω(res.String()).Should(Equal("09:30:00"))
+
+	res, err = ParseTimeOfDay("23:59:58")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(TimeOfDay{Hour: 23, Minute: 59, Second: 58}))
+
+	res, err = ParseTimeOfDay("23:59:58.25")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(TimeOfDay{23, 59, 58, 250000000}))
+	Ω(res.String()).Should(Equal("23:59:58.25"))
+
+	_, err = ParseTimeOfDay("24:00")
+	Ω(err).ShouldNot(BeNil())
+
+	_, err = ParseTimeOfDay("9")
+	Ω(err).ShouldNot(BeNil())
+
+	_, err = ParseTimeOfDay("")
+	Ω(err).ShouldNot(BeNil())
+}
+
+func TestParseDateRange(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res DateRange
+	var err error
+
+	res, err = ParseDateRange("2016-02-27/2016-03-01")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(DateRange{
+		Start: Date{2016, time.February, 27},
+		End:   Date{2016, time.March, 1},
+	}))
+	Ω(res.String()).Should(Equal("2016-02-27/2016-03-01"))
+	Ω(res.Days()).Should(Equal(4))
+	Ω(res.Contains(Date{2016, time.February, 27})).Should(BeTrue())
+	Ω(res.Contains(Date{2016, time.February, 29})).Should(BeTrue())
+	Ω(res.Contains(Date{2016, time.March, 1})).Should(BeTrue())
+	Ω(res.Contains(Date{2016, time.March, 2})).Should(BeFalse())
+	Ω(res.Contains(Date{2015, time.March, 1})).Should(BeFalse())
+
+	days := []Date{}
+	res.ForEach(func(d Date) { days = append(days, d) })
+	Ω(days).Should(Equal([]Date{
+		{2016, time.February, 27},
+		{2016, time.February, 28},
+		{2016, time.February, 29},
+		{2016, time.March, 1},
+	}))
+
+	loc := time.FixedZone("EST", -5*60*60)
+	start, end := res.In(loc)
+	Ω(start).Should(Equal(time.Date(2016, 2, 27, 0, 0, 0, 0, loc)))
+	Ω(end).Should(Equal(time.Date(2016, 3, 2, 0, 0, 0, 0, loc)))
+
+	res, err = ParseDateRange("2016-02-27/2016-02-27")
+	Ω(err).Should(BeNil())
+	Ω(res.Days()).Should(Equal(1))
+
+	res, err = ParseDateRange("0001-01-01/9999-12-31")
+	Ω(err).Should(BeNil())
+	Ω(res.Days()).Should(Equal(3652059))
+
+	_, err = ParseDateRange("2016-02-27/2016-02-26")
+	Ω(err).Should(Equal(InvalidRangeErr))
+
+	_, err = ParseDateRange("2016-02-27")
+	Ω(err).ShouldNot(BeNil())
+
+	_, err = ParseDateRange("2016-02-27/")
+	Ω(err).ShouldNot(BeNil())
+}
+
+func TestParseTimeOfDayRange(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res TimeOfDayRange
+	var err error
+
+	loc := time.FixedZone("EST", -5*60*60)
+	d := Date{2016, time.February, 29}
+
+	res, err = ParseTimeOfDayRange("09:00/17:30")
+	Ω(err).Should(BeNil())
+	Ω(res.Contains(TimeOfDay{Hour: 9})).Should(BeTrue())
+	Ω(res.Contains(TimeOfDay{Hour: 17, Minute: 29})).Should(BeTrue())
+	Ω(res.Contains(TimeOfDay{Hour: 17, Minute: 30})).Should(BeFalse())
+	Ω(res.Contains(TimeOfDay{Hour: 8, Minute: 59})).Should(BeFalse())
+
+	start, end := res.On(d, loc)
+	Ω(start).Should(Equal(time.Date(2016, 2, 29, 9, 0, 0, 0, loc)))
+	Ω(end).Should(Equal(time.Date(2016, 2, 29, 17, 30, 0, 0, loc)))
+
+	res, err = ParseTimeOfDayRange("22:00/06:00")
+	Ω(err).Should(BeNil())
+	Ω(res.String()).Should(Equal("22:00:00/06:00:00"))
+	Ω(res.Contains(TimeOfDay{Hour: 23})).Should(BeTrue())
+	Ω(res.Contains(TimeOfDay{Hour: 2})).Should(BeTrue())
+	Ω(res.Contains(TimeOfDay{Hour: 6})).Should(BeFalse())
+	Ω(res.Contains(TimeOfDay{Hour: 12})).Should(BeFalse())
+
+	start, end = res.On(d, loc)
+	Ω(start).Should(Equal(time.Date(2016, 2, 29, 22, 0, 0, 0, loc)))
+	Ω(end).Should(Equal(time.Date(2016, 3, 1, 6, 0, 0, 0, loc)))
+
+	_, err = ParseTimeOfDayRange("22:00")
+	Ω(err).ShouldNot(BeNil())
+}
+
+func TestStringValueDate(t *testing.T) {
+	RegisterTestingT(t)
+
+	var v *StringValue
+	var res Date
+	var err error
+
+	def := Date{1860, time.July, 2}
+
+	v = psv("2016-02-03")
+
+	res, err = v.ParseDate()
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(Date{2016, time.February, 3}))
+
+	res = v.Date(def)
+	Ω(res).Should(Equal(Date{2016, time.February, 3}))
+
+	v = nil
+
+	_, err = v.ParseDate()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+
+	res = v.Date()
+	Ω(res).Should(Equal(Date{}))
+	Ω(res.IsZero()).Should(BeTrue())
+
+	res = v.Date(def)
+	Ω(res).Should(Equal(def))
+
+	v = psv("2016-02-30")
+
+	_, err = v.ParseDate()
+	Ω(err).ShouldNot(BeNil())
+
+	res = v.Date(def)
+	Ω(res).Should(Equal(def))
+}
+
+func TestStringValueTimeOfDay(t *testing.T) {
+	RegisterTestingT(t)
+
+	var v *StringValue
+	var res TimeOfDay
+	var err error
+
+	def := TimeOfDay{Hour: 12}
+
+	v = psv("15:04:05")
+
+	res, err = v.ParseTimeOfDay()
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(TimeOfDay{Hour: 15, Minute: 4, Second: 5}))
+
+	res = v.TimeOfDay(def)
+	Ω(res).Should(Equal(TimeOfDay{Hour: 15, Minute: 4, Second: 5}))
+
+	v = nil
+
+	_, err = v.ParseTimeOfDay()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+
+	res = v.TimeOfDay()
+	Ω(res).Should(Equal(TimeOfDay{}))
+
+	res = v.TimeOfDay(def)
+	Ω(res).Should(Equal(def))
+
+	v = psv("noon")
+
+	_, err = v.ParseTimeOfDay()
+	Ω(err).ShouldNot(BeNil())
+
+	res = v.TimeOfDay(def)
+	Ω(res).Should(Equal(def))
+}
+
+func TestStringValueDateRange(t *testing.T) {
+	RegisterTestingT(t)
+
+	var v *StringValue
+	var err error
+
+	v = psv("2016-02-01/2016-02-29")
+	Ω(v.DateRange()).Should(Equal(DateRange{
+		Start: Date{2016, time.February, 1},
+		End:   Date{2016, time.February, 29},
+	}))
+
+	v = nil
+	_, err = v.ParseDateRange()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	Ω(v.DateRange()).Should(Equal(DateRange{}))
+
+	v = psv("09:00/17:00")
+	Ω(v.TimeOfDayRange()).Should(Equal(TimeOfDayRange{
+		Start: TimeOfDay{Hour: 9},
+		End:   TimeOfDay{Hour: 17},
+	}))
+	_, err = v.ParseDateRange()
+	Ω(err).ShouldNot(BeNil())
+}
+
+func TestValueSetDates(t *testing.T) {
+	RegisterTestingT(t)
+
+	vs := ValueSetFrom([]string{"2016-02-03", "", "2016-02-29"})
+	Ω(vs.Dates()).Should(Equal([]Date{
+		{2016, time.February, 3},
+		{},
+		{2016, time.February, 29},
+	}))
+
+	vs = ValueSetFrom([]string{"09:30", "x"})
+	Ω(vs.TimesOfDay()).Should(Equal([]TimeOfDay{
+		{Hour: 9, Minute: 30},
+		{},
+	}))
+}
@@ -0,0 +1,254 @@
+package simplequery
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	InvalidDecodeTargetErr = errors.New("The decoding target is not a pointer to a struct")
+	UnknownTagOptionErr    = errors.New("The query tag has an unknown option")
+)
+
+// DecodeOptions control how Decode fills the fields of a struct.
+type DecodeOptions struct {
+	// Registry provides the parsers, DefaultRegistry is used if nil.
+	Registry *Registry
+	// JSON controls the decoding of the fields with the json tag option.
+	JSON JSONOptions
+}
+
+// DecodeError reports a parameter that cannot be decoded into its field.
+type DecodeError struct {
+	// Key is the name of the parameter.
+	Key string
+	// Field is the name of the struct field.
+	Field string
+	Err   error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decoding %q into %s: %v", e.Key, e.Field, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Decode fills the fields of the struct dst points to with the values of q.
+// A field is read from the parameter named by its query tag, e.g.
+// `query:"limit"`, or by the field itself if the tag has no name. Fields
+// tagged `query:"-"`, unexported fields and the fields of missing parameters
+// are left unchanged. The fields of untagged embedded structs are decoded as
+// if they belonged to dst.
+//
+// A field may be of any type the registry can parse, a pointer to such a
+// type, which is allocated when the parameter is present, or a slice of such
+// a type, which is filled from all the values of the parameter. A map field
+// with string keys and values of such a type is filled from the pairs given
+// as "key=k1:v1,k2:v2" or, if q provides Map as Q and TrackedQ do, as
+// "key[k1]=v1"; the first value of a repeated pair is used. A field with the
+// json option, e.g. `query:"filter,json"`, is decoded from the first value
+// with StringValue.JSON instead.
+//
+// If q is a Resolver, the fields are read from the values picked by the
+// policies, and a value rejected by a policy fails the decoding. Slices are
+// filled from all the values only for the keys with a nil policy, and the
+// policy of a map field picks the values of its pairs.
+func Decode(q Getter, dst interface{}, opts ...DecodeOptions) error {
+	o := DecodeOptions{}
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T", InvalidDecodeTargetErr, dst)
+	}
+	d := &decoder{q: q, opts: o, reg: o.Registry}
+	if d.reg == nil {
+		d.reg = DefaultRegistry
+	}
+	return d.decodeStruct(v.Elem())
+}
+
+type decoder struct {
+	q    Getter
+	opts DecodeOptions
+	reg  *Registry
+}
+
+func (d *decoder) decodeStruct(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, tagged := f.Tag.Lookup("query")
+		if tag == "-" {
+			continue
+		}
+		if f.Anonymous && !tagged && f.Type.Kind() == reflect.Struct {
+			if err := d.decodeStruct(v.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		key, opt, _ := strings.Cut(tag, ",")
+		if key == "" {
+			key = f.Name
+		}
+		if err := d.decodeField(v.Field(i), key, opt); err != nil {
+			return &DecodeError{Key: key, Field: f.Name, Err: err}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeField(v reflect.Value, key, opt string) error {
+	switch opt {
+	case "":
+	case "json":
+		s, err := d.get(key)
+		if s == nil || err != nil {
+			return err
+		}
+		return s.JSON(v.Addr().Interface(), d.opts.JSON)
+	default:
+		return fmt.Errorf("%w: %q", UnknownTagOptionErr, opt)
+	}
+
+	t := v.Type()
+	switch {
+	case d.reg.canParse(t):
+		s, err := d.get(key)
+		if s == nil || err != nil {
+			return err
+		}
+		return d.parse(v, s)
+
+	case t.Kind() == reflect.Ptr && d.reg.canParse(t.Elem()):
+		s, err := d.get(key)
+		if s == nil || err != nil {
+			return err
+		}
+		ptr := reflect.New(t.Elem())
+		if err := d.parse(ptr.Elem(), s); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+
+	case t.Kind() == reflect.Slice && d.reg.canParse(t.Elem()):
+		vs, err := d.getAll(key)
+		if len(vs) == 0 || err != nil {
+			return err
+		}
+		res := reflect.MakeSlice(t, len(vs), len(vs))
+		for i := range vs {
+			if err := d.parse(res.Index(i), &vs[i]); err != nil {
+				return &IndexError{i, err}
+			}
+		}
+		v.Set(res)
+		return nil
+
+	case t.Kind() == reflect.Map && t.Key().Kind() == reflect.String && d.reg.canParse(t.Elem()):
+		m, policy, err := d.getMap(key)
+		if len(m) == 0 || err != nil {
+			return err
+		}
+		res := reflect.MakeMapWithSize(t, len(m))
+		for k, vs := range m {
+			val, err := policy(vs)
+			if err != nil {
+				return fmt.Errorf("%s: %w", k, err)
+			}
+			if val == nil {
+				continue
+			}
+			elem := reflect.New(t.Elem()).Elem()
+			if err := d.parse(elem, val); err != nil {
+				return fmt.Errorf("%s: %w", k, err)
+			}
+			res.SetMapIndex(reflect.ValueOf(k).Convert(t.Key()), elem)
+		}
+		v.Set(res)
+		return nil
+	}
+	return fmt.Errorf("%w: %v", UnsupportedTypeErr, t)
+}
+
+// get returns the first value of key, or the value picked by the policy of
+// key if q is a Resolver.
+func (d *decoder) get(key string) (*StringValue, error) {
+	if r, ok := d.q.(valueResolver); ok {
+		return r.Resolve(key)
+	}
+	return d.q.Get(key), nil
+}
+
+// getAll returns all the values of key, or the value picked by the policy of
+// key if q is a Resolver and the policy is not nil.
+func (d *decoder) getAll(key string) (ValueSet, error) {
+	if r, ok := d.q.(*Resolver); ok && r.policy(key) != nil {
+		val, err := r.Resolve(key)
+		if val == nil {
+			return ValueSet{}, err
+		}
+		return ValueSet{*val}, err
+	}
+	return d.q.GetAll(key), nil
+}
+
+// getMap collects the pairs of key given as "key=k1:v1,k2:v2" or, if q
+// provides Map as Q and TrackedQ do, as "key[k1]=v1". It returns them with
+// the policy picking the value of every pair: the policy of key if q is a
+// Resolver, FirstValue otherwise.
+func (d *decoder) getMap(key string) (map[string]ValueSet, Policy, error) {
+	q, policy := d.q, Policy(FirstValue)
+	if r, ok := q.(*Resolver); ok {
+		q = r.Q
+		if p := r.policy(key); p != nil {
+			policy = p
+		}
+	}
+
+	res := map[string]ValueSet{}
+	vs := q.GetAll(key)
+	for i := range vs {
+		m, err := vs[i].ParseMap(",", ":")
+		if err != nil {
+			return nil, nil, err
+		}
+		for k, kvs := range m {
+			res[k] = append(res[k], kvs...)
+		}
+	}
+	if mq, ok := q.(interface {
+		Map(prefix string) map[string]ValueSet
+	}); ok {
+		for k, kvs := range mq.Map(key) {
+			res[k] = append(res[k], kvs...)
+		}
+	}
+	return res, policy, nil
+}
+
+// parse converts s to the type of v and stores the result in v.
+func (d *decoder) parse(v reflect.Value, s *StringValue) error {
+	val, err := d.reg.Parse(v.Type(), s)
+	if err != nil {
+		return err
+	}
+	if val == nil {
+		v.Set(reflect.Zero(v.Type()))
+	} else {
+		v.Set(reflect.ValueOf(val))
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+package simplequery
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+type testPage struct {
+	Limit  int `query:"limit"`
+	Offset int `query:"offset"`
+}
+
+type testSearch struct {
+	testPage
+	Query   string        `query:"q"`
+	Tags    []string      `query:"tag"`
+	IDs     []int64       `query:"id"`
+	Exact   *bool         `query:"exact"`
+	Since   *Date         `query:"since"`
+	Timeout time.Duration `query:"timeout"`
+	Filter  testFilter    `query:"filter,json"`
+	Sort    string
+	Ignored string `query:"-"`
+	secret  string
+}
+
+func TestDecode(t *testing.T) {
+	RegisterTestingT(t)
+
+	q := FromQuery(map[string][]string{
+		"limit":   {"10", "20"},
+		"q":       {"go"},
+		"tag":     {"a", "b"},
+		"id":      {"1", "2"},
+		"exact":   {"true"},
+		"since":   {"2024-02-29"},
+		"timeout": {"1m30s"},
+		"filter":  {`{"status":"open","limit":5}`},
+		"Sort":    {"name"},
+		"Ignored": {"x"},
+		"-":       {"x"},
+		"secret":  {"x"},
+	})
+
+	dst := testSearch{testPage: testPage{Offset: 7}, Ignored: "keep"}
+	Ω(Decode(q, &dst)).Should(Succeed())
+
+	exact := true
+	Ω(dst).Should(Equal(testSearch{
+		testPage: testPage{Limit: 10, Offset: 7},
+		Query:    "go",
+		Tags:     []string{"a", "b"},
+		IDs:      []int64{1, 2},
+		Exact:    &exact,
+		Since:    &Date{2024, time.February, 29},
+		Timeout:  90 * time.Second,
+		Filter:   testFilter{Status: "open", Limit: 5},
+		Sort:     "name",
+		Ignored:  "keep",
+	}))
+
+	// Missing parameters leave the fields unchanged.
+	dst = testSearch{Query: "keep", Tags: []string{"keep"}}
+	Ω(Decode(NewQ(), &dst)).Should(Succeed())
+	Ω(dst).Should(Equal(testSearch{Query: "keep", Tags: []string{"keep"}}))
+}
+
+func TestDecode_Errors(t *testing.T) {
+	RegisterTestingT(t)
+
+	var dst testSearch
+	var err error
+	var derr *DecodeError
+
+	err = Decode(FromQuery(map[string][]string{"id": {"1", "x"}}), &dst)
+	Ω(errors.As(err, &derr)).Should(BeTrue())
+	Ω(derr.Key).Should(Equal("id"))
+	Ω(derr.Field).Should(Equal("IDs"))
+	Ω(err.Error()).Should(HavePrefix(`decoding "id" into IDs: value #1: `))
+
+	err = Decode(FromQuery(map[string][]string{"filter": {`{"status":`}}), &dst)
+	Ω(errors.As(err, &derr)).Should(BeTrue())
+	Ω(derr.Key).Should(Equal("filter"))
+
+	err = Decode(FromQuery(map[string][]string{"filter": {`{"status":"open","x":1}`}}), &dst,
+		DecodeOptions{JSON: JSONOptions{DisallowUnknownFields: true}})
+	Ω(errors.As(err, &derr)).Should(BeTrue())
+	Ω(derr.Key).Should(Equal("filter"))
+
+	err = Decode(NewQ(), dst)
+	Ω(errors.Is(err, InvalidDecodeTargetErr)).Should(BeTrue())
+	err = Decode(NewQ(), (*testSearch)(nil))
+	Ω(errors.Is(err, InvalidDecodeTargetErr)).Should(BeTrue())
+
+	var unsupported struct {
+		Ch chan int `query:"ch"`
+	}
+	err = Decode(NewQ(), &unsupported)
+	Ω(errors.Is(err, UnsupportedTypeErr)).Should(BeTrue())
+
+	var unknown struct {
+		Filter testFilter `query:"filter,yaml"`
+	}
+	err = Decode(NewQ(), &unknown)
+	Ω(errors.Is(err, UnknownTagOptionErr)).Should(BeTrue())
+}
+
+func TestDecode_IntSyntax(t *testing.T) {
+	RegisterTestingT(t)
+
+	defer func(syn IntSyntax) { DefaultIntSyntax = syn }(DefaultIntSyntax)
+
+	q := FromQuery(map[string][]string{"limit": {"010"}, "offset": {"0x10"}})
+
+	var dst testPage
+	DefaultIntSyntax = DecimalInts
+	err := Decode(q, &dst)
+	Ω(errors.Is(err, strconv.ErrSyntax)).Should(BeTrue())
+	Ω(dst.Limit).Should(Equal(10))
+
+	dst = testPage{}
+	DefaultIntSyntax = BasePrefixInts
+	Ω(Decode(q, &dst)).Should(Succeed())
+	Ω(dst).Should(Equal(testPage{Limit: 8, Offset: 16}))
+}
+
+func TestDecode_Registry(t *testing.T) {
+	RegisterTestingT(t)
+
+	type accountID string
+	var dst struct {
+		Account accountID   `query:"account"`
+		Related []accountID `query:"related"`
+		Flag    bool        `query:"flag"`
+	}
+
+	q := FromQuery(map[string][]string{
+		"account": {"acc-1"},
+		"related": {"acc-2", "acc-3"},
+		"flag":    {"yes"},
+	})
+
+	err := Decode(q, &dst)
+	Ω(errors.Is(err, UnsupportedTypeErr)).Should(BeTrue())
+
+	r := NewRegistry()
+	Register(r, func(str string) (accountID, error) {
+		return accountID(strings.TrimPrefix(str, "acc-")), nil
+	}, nil)
+	Register(r, func(str string) (bool, error) {
+		return str == "yes", nil
+	}, nil)
+
+	Ω(Decode(q, &dst, DecodeOptions{Registry: r})).Should(Succeed())
+	Ω(dst.Account).Should(Equal(accountID("1")))
+	Ω(dst.Related).Should(Equal([]accountID{"2", "3"}))
+	Ω(dst.Flag).Should(BeTrue())
+}
+
+func TestDecode_Resolver(t *testing.T) {
+	RegisterTestingT(t)
+
+	q := FromQuery(map[string][]string{
+		"limit":  {"10", "1000"},
+		"offset": {"5", "7"},
+		"id":     {"1", "2"},
+		"tag":    {"a,b", "c"},
+	})
+
+	var dst testSearch
+	var err error
+
+	r := (&Resolver{Q: q, Default: SingleValue}).
+		Policy(LastValue, "offset").
+		Policy(nil, "id").
+		Policy(UnionValues(","), "tag")
+
+	err = Decode(r, &dst)
+	Ω(errors.Is(err, DuplicateValueErr)).Should(BeTrue())
+	var derr *DecodeError
+	Ω(errors.As(err, &derr)).Should(BeTrue())
+	Ω(derr.Key).Should(Equal("limit"))
+
+	dst = testSearch{}
+	Ω(Decode(r.Policy(FirstValue, "limit"), &dst)).Should(Succeed())
+	Ω(dst.testPage).Should(Equal(testPage{Limit: 10, Offset: 7}))
+	Ω(dst.IDs).Should(Equal([]int64{1, 2}))
+	Ω(dst.Tags).Should(Equal([]string{"a,b,c"}))
+}
+
+func TestDecode_Map(t *testing.T) {
+	RegisterTestingT(t)
+
+	type label string
+	var dst struct {
+		Labels map[label]string `query:"labels"`
+		Limits map[string]int   `query:"limit"`
+		Meta   map[string]Date  `query:"meta"`
+	}
+
+	q := FromQuery(map[string][]string{
+		"labels":      {"env:prod,team:core", "env:dev"},
+		"limit[read]": {"10"},
+		"limit":       {"write:5"},
+		"limit[]":     {"1"},
+	})
+
+	dst.Meta = map[string]Date{"keep": {}}
+	Ω(Decode(q, &dst)).Should(Succeed())
+	Ω(dst.Labels).Should(Equal(map[label]string{"env": "prod", "team": "core"}))
+	Ω(dst.Limits).Should(Equal(map[string]int{"read": 10, "write": 5}))
+	Ω(dst.Meta).Should(Equal(map[string]Date{"keep": {}}))
+
+	var err error
+	err = Decode(q.Resolver(SingleValue), &dst)
+	Ω(errors.Is(err, DuplicateValueErr)).Should(BeTrue())
+	Ω(err.Error()).Should(HavePrefix(`decoding "labels" into Labels: env: `))
+
+	err = Decode(FromQuery(map[string][]string{"limit[read]": {"x"}}), &dst)
+	Ω(errors.Is(err, strconv.ErrSyntax)).Should(BeTrue())
+	Ω(err.Error()).Should(HavePrefix(`decoding "limit" into Limits: read: `))
+
+	err = Decode(FromQuery(map[string][]string{"labels": {":x"}}), &dst)
+	Ω(err.(*DecodeError).Err.(*ParseError).Kind).Should(Equal("map"))
+}
+
+func TestDecode_Tracked(t *testing.T) {
+	RegisterTestingT(t)
+
+	var dst struct {
+		testPage
+		Labels map[string]string `query:"labels"`
+		Tags   []string          `query:"tag"`
+	}
+
+	tq := FromQuery(map[string][]string{
+		"limit":         {"10"},
+		"ofset":         {"5"},
+		"labels[env]":   {"prod"},
+		"labels[a][b]":  {"x"},
+		"tag":           {"a", "b"},
+		"unrelated_key": {"x"},
+	}).Track()
+
+	Ω(Decode(tq, &dst)).Should(Succeed())
+	Ω(tq.Unconsumed()).Should(Equal([]string{"labels[a][b]", "ofset", "unrelated_key"}))
+
+	err := tq.Strict()
+	var uerr *UnexpectedParamsError
+	Ω(errors.As(err, &uerr)).Should(BeTrue())
+	Ω(uerr.Suggestions).Should(HaveKeyWithValue("ofset", "offset"))
+
+	// Reads through a Resolver are tracked as well.
+	tq = FromQuery(map[string][]string{"limit": {"1"}, "labels[env]": {"prod"}}).Track()
+	Ω(Decode(&Resolver{Q: tq, Default: SingleValue}, &dst)).Should(Succeed())
+	Ω(tq.Unconsumed()).Should(Equal([]string{}))
+}
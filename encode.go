@@ -0,0 +1,169 @@
+package simplequery
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ListStyle selects how Encode renders the values of a key.
+type ListStyle int
+
+const (
+	// RepeatList repeats the key, e.g. "k=a&k=b".
+	RepeatList ListStyle = iota
+	// CommaList joins the values with commas, e.g. "k=a,b". It applies to
+	// keys with a single value too. Commas and percent signs within the
+	// values are escaped twice, e.g. "a,b" becomes "a%252Cb", so that they
+	// survive the decoding of the query; split such values with
+	// StringValue.ParseCommaList rather than List.
+	CommaList
+	// BracketList appends empty brackets to the key, e.g. "k[]=a&k[]=b". It
+	// applies to keys with a single value too.
+	BracketList
+	// IndexList appends the value index to the key, e.g. "k[0]=a&k[1]=b". It
+	// applies to keys with a single value too.
+	IndexList
+)
+
+// EncodeOptions control how Q.Encode renders the query.
+type EncodeOptions struct {
+	// SortValues sorts the values of every key rather than keeping their
+	// order.
+	SortValues bool
+	// PercentSpaces escapes spaces as "%20" rather than "+".
+	PercentSpaces bool
+	// EmptyAsFlag renders empty values as "k" rather than "k=".
+	EmptyAsFlag bool
+	// ListStyle is the style of the keys with values.
+	ListStyle ListStyle
+}
+
+// Encode renders q as a query string with the keys sorted, so that equal
+// queries always produce the same string. Keys without any values are left
+// out. Otherwise, with the default options FromQuery parses the result back
+// to a Q equal to q. With CommaList, split the values with
+// StringValue.ParseCommaList; with BracketList and IndexList, collect them
+// with Q.List.
+func (q Q) Encode(opts ...EncodeOptions) string {
+	o := EncodeOptions{}
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	write := func(key, val string, escapeVal bool) {
+		if b.Len() > 0 {
+			b.WriteByte('&')
+		}
+		b.WriteString(o.escape(key))
+		if val == "" && o.EmptyAsFlag {
+			return
+		}
+		b.WriteByte('=')
+		if escapeVal {
+			val = o.escape(val)
+		}
+		b.WriteString(val)
+	}
+
+	for _, k := range keys {
+		vs := q[k].Strings()
+		if o.SortValues {
+			sort.Strings(vs)
+		}
+
+		switch {
+		case len(vs) == 0:
+		case o.ListStyle == CommaList:
+			for i := range vs {
+				vs[i] = o.escape(commaListEscaper.Replace(vs[i]))
+			}
+			write(k, strings.Join(vs, ","), false)
+		case o.ListStyle == BracketList:
+			for _, v := range vs {
+				write(k+"[]", v, true)
+			}
+		case o.ListStyle == IndexList:
+			for i, v := range vs {
+				write(k+"["+strconv.Itoa(i)+"]", v, true)
+			}
+		default:
+			for _, v := range vs {
+				write(k, v, true)
+			}
+		}
+	}
+	return b.String()
+}
+
+var commaListEscaper = strings.NewReplacer("%", "%25", ",", "%2C")
+
+// ParseCommaList splits a value encoded by Q.Encode with CommaList into the
+// original values.
+func (s *StringValue) ParseCommaList() (ValueSet, error) {
+	if s == nil {
+		return ValueSet{}, UnspecifiedValueErr
+	}
+
+	str := string(*s)
+	parts := strings.Split(str, ",")
+	res := make(ValueSet, len(parts))
+	for i, part := range parts {
+		val, err := url.PathUnescape(part)
+		if err != nil {
+			return ValueSet{}, &ParseError{"list", str, err}
+		}
+		res[i] = StringValue(val)
+	}
+	return res, nil
+}
+
+// List collects the values of key written in any of the list styles but
+// CommaList: the values of "key" come first, then those of "key[]" and then
+// those of "key[0]", "key[1]" and so on in the order of the indexes.
+func (q Q) List(key string) ValueSet {
+	res := append(ValueSet{}, q[key]...)
+	res = append(res, q[key+"[]"]...)
+
+	type indexed struct {
+		index int
+		vs    ValueSet
+	}
+	var items []indexed
+	for k, vs := range q {
+		if i, ok := listIndex(k, key); ok {
+			items = append(items, indexed{i, vs})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].index < items[j].index })
+	for _, item := range items {
+		res = append(res, item.vs...)
+	}
+	return res
+}
+
+// listIndex returns the index of a key[index] parameter.
+func listIndex(param, key string) (int, bool) {
+	idx, ok := mapKey(param, key)
+	if !ok || idx[0] < '0' || idx[0] > '9' {
+		return 0, false
+	}
+	i, err := strconv.Atoi(idx)
+	return i, err == nil
+}
+
+func (o EncodeOptions) escape(str string) string {
+	str = url.QueryEscape(str)
+	if o.PercentSpaces {
+		str = strings.ReplaceAll(str, "+", "%20")
+	}
+	return str
+}
@@ -0,0 +1,154 @@
+package simplequery
+
+import (
+	"net/url"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestQEncode(t *testing.T) {
+	RegisterTestingT(t)
+
+	q := Q{
+		"b":    ValueSetFrom([]string{"2", "1"}),
+		"a":    ValueSetFrom([]string{"x y"}),
+		"flag": ValueSetFrom([]string{""}),
+		"none": ValueSet{},
+	}
+
+	Ω(q.Encode()).Should(Equal("a=x+y&b=2&b=1&flag="))
+	Ω(q.Encode(EncodeOptions{SortValues: true})).Should(Equal("a=x+y&b=1&b=2&flag="))
+	Ω(q.Encode(EncodeOptions{PercentSpaces: true})).Should(Equal("a=x%20y&b=2&b=1&flag="))
+	Ω(q.Encode(EncodeOptions{EmptyAsFlag: true})).Should(Equal("a=x+y&b=2&b=1&flag"))
+	Ω(q.Encode(EncodeOptions{ListStyle: CommaList})).Should(Equal("a=x+y&b=2,1&flag="))
+	Ω(q.Encode(EncodeOptions{ListStyle: BracketList})).Should(Equal("a%5B%5D=x+y&b%5B%5D=2&b%5B%5D=1&flag%5B%5D="))
+	Ω(q.Encode(EncodeOptions{ListStyle: IndexList})).Should(Equal("a%5B0%5D=x+y&b%5B0%5D=2&b%5B1%5D=1&flag%5B0%5D="))
+
+	Ω(NewQ().Encode()).Should(Equal(""))
+
+	// Commas and percent signs within values are escaped twice.
+	q = Q{"k": ValueSetFrom([]string{"a,b", "c"}), "p": ValueSetFrom([]string{"5%,x"})}
+	Ω(q.Encode(EncodeOptions{ListStyle: CommaList})).Should(Equal("k=a%252Cb,c&p=5%2525%252Cx"))
+}
+
+func TestQEncode_RoundTrip(t *testing.T) {
+	RegisterTestingT(t)
+
+	q := Q{
+		"plain":  ValueSetFrom([]string{"abc"}),
+		"space":  ValueSetFrom([]string{"a b", " "}),
+		"plus":   ValueSetFrom([]string{"1+1=2"}),
+		"amp":    ValueSetFrom([]string{"a&b", "c;d"}),
+		"empty":  ValueSetFrom([]string{"", "x", ""}),
+		"utf8":   ValueSetFrom([]string{"привет", "日本"}),
+		"pct":    ValueSetFrom([]string{"100%", "%20"}),
+		"k e=y&": ValueSetFrom([]string{"v"}),
+		"":       ValueSetFrom([]string{"no key"}),
+	}
+
+	for _, o := range []EncodeOptions{
+		{},
+		{PercentSpaces: true},
+		{EmptyAsFlag: true},
+		{PercentSpaces: true, EmptyAsFlag: true},
+	} {
+		str := q.Encode(o)
+		vals, err := url.ParseQuery(str)
+		Ω(err).Should(BeNil(), str)
+		Ω(FromQuery(vals)).Should(Equal(q), str)
+		Ω(q.Clone().Encode(o)).Should(Equal(str))
+	}
+
+	// Keys without values are left out.
+	vals, err := url.ParseQuery(Q{"none": ValueSet{}, "a": ValueSetFrom([]string{"1"})}.Encode())
+	Ω(err).Should(BeNil())
+	Ω(FromQuery(vals)).Should(Equal(Q{"a": ValueSetFrom([]string{"1"})}))
+}
+
+func TestQEncode_ListRoundTrip(t *testing.T) {
+	RegisterTestingT(t)
+
+	q := Q{
+		"plain": ValueSetFrom([]string{"abc"}),
+		"space": ValueSetFrom([]string{"a b", " "}),
+		"amp":   ValueSetFrom([]string{"a&b", "c[]"}),
+		"empty": ValueSetFrom([]string{"", "x", ""}),
+		"utf8":  ValueSetFrom([]string{"привет", "日本"}),
+		"one":   ValueSetFrom([]string{""}),
+		"many":  ValueSetFrom([]string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"}),
+		"":      ValueSetFrom([]string{"no key"}),
+	}
+
+	for _, o := range []EncodeOptions{
+		{ListStyle: BracketList},
+		{ListStyle: IndexList},
+		{ListStyle: IndexList, PercentSpaces: true, EmptyAsFlag: true},
+	} {
+		str := q.Encode(o)
+		vals, err := url.ParseQuery(str)
+		Ω(err).Should(BeNil(), str)
+		parsed := FromQuery(vals)
+		for k, vs := range q {
+			Ω(parsed.Has(k)).Should(BeFalse(), k)
+			Ω(parsed.List(k)).Should(Equal(vs), k)
+		}
+	}
+}
+
+func TestQList(t *testing.T) {
+	RegisterTestingT(t)
+
+	q := FromQuery(map[string][]string{
+		"k":      {"a"},
+		"k[]":    {"b", "c"},
+		"k[10]":  {"e"},
+		"k[2]":   {"d"},
+		"k[x]":   {"x"},
+		"k[-1]":  {"x"},
+		"k[0][]": {"x"},
+		"kk[]":   {"x"},
+	})
+
+	Ω(q.List("k").Strings()).Should(Equal([]string{"a", "b", "c", "d", "e"}))
+	Ω(q.List("missing")).Should(Equal(ValueSet{}))
+
+	tq := q.Track()
+	Ω(tq.List("k").Strings()).Should(Equal([]string{"a", "b", "c", "d", "e"}))
+	Ω(tq.Unconsumed()).Should(Equal([]string{"k[-1]", "k[0][]", "k[x]", "kk[]"}))
+}
+
+func TestQEncode_CommaListRoundTrip(t *testing.T) {
+	RegisterTestingT(t)
+
+	q := Q{
+		"comma":  ValueSetFrom([]string{"a,b", "c"}),
+		"single": ValueSetFrom([]string{"x,y"}),
+		"pct":    ValueSetFrom([]string{"100%", "%2C", "%"}),
+		"plus":   ValueSetFrom([]string{"1+1", "a b"}),
+		"amp":    ValueSetFrom([]string{"a&b", "c;d"}),
+		"empty":  ValueSetFrom([]string{"", ",", ""}),
+		"one":    ValueSetFrom([]string{""}),
+	}
+
+	for _, o := range []EncodeOptions{
+		{ListStyle: CommaList},
+		{ListStyle: CommaList, PercentSpaces: true, EmptyAsFlag: true},
+	} {
+		str := q.Encode(o)
+		vals, err := url.ParseQuery(str)
+		Ω(err).Should(BeNil(), str)
+		parsed := FromQuery(vals)
+		Ω(parsed).Should(HaveLen(len(q)), str)
+		for k, vs := range q {
+			Ω(parsed.GetAll(k)).Should(HaveLen(1), k)
+			Ω(parsed.Get(k).ParseCommaList()).Should(Equal(vs), k)
+		}
+	}
+
+	var err error
+	_, err = psv("a,%zz").ParseCommaList()
+	Ω(err.(*ParseError).Kind).Should(Equal("list"))
+	_, err = (*StringValue)(nil).ParseCommaList()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+}
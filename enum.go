@@ -0,0 +1,212 @@
+package simplequery
+
+import (
+	"encoding"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ParseEnum matches str against the allowed values ignoring case and returns
+// the allowed value as spelled in the list.
+func ParseEnum(str string, allowed ...string) (string, error) {
+	for _, a := range allowed {
+		if strings.EqualFold(str, a) {
+			return a, nil
+		}
+	}
+	return "", unknownEnumErr(str, allowed)
+}
+
+func unknownEnumErr(str string, allowed []string) error {
+	return &ParseError{"enum", str,
+		fmt.Errorf("expected one of %s", strings.Join(allowed, ", "))}
+}
+
+func (s *StringValue) ParseEnum(allowed ...string) (string, error) {
+	if s == nil {
+		return "", UnspecifiedValueErr
+	}
+	return ParseEnum(string(*s), allowed...)
+}
+
+// Enum returns the matching allowed value, or an empty string if the value
+// is missing or not allowed. Use ParseEnum to tell these cases apart.
+func (s *StringValue) Enum(allowed ...string) string {
+	val, _ := s.ParseEnum(allowed...)
+	return val
+}
+
+func (s ValueSet) Enums(allowed ...string) []string {
+	res := make([]string, len(s))
+	for i := range s {
+		res[i] = s[i].Enum(allowed...)
+	}
+	return res
+}
+
+func (s ValueSet) ParseEnums(allowed []string, mode ...PluralMode) ([]string, error) {
+	return parseValues(s, func(v *StringValue) (string, error) {
+		return v.ParseEnum(allowed...)
+	}, mode...)
+}
+
+// ParseText decodes the value into dst using its UnmarshalText method.
+func (s *StringValue) ParseText(dst encoding.TextUnmarshaler) error {
+	if s == nil {
+		return UnspecifiedValueErr
+	}
+	return dst.UnmarshalText([]byte(*s))
+}
+
+// EnumType maps case-insensitive names, and optionally aliases, to Go
+// constants of type T:
+//
+//	var statuses = NewEnumType(map[string]Status{
+//		"open":   StatusOpen,
+//		"closed": StatusClosed,
+//	}).Alias("done", StatusClosed)
+//
+// Every value must have a single canonical name in the map; register other
+// spellings with Alias. It should be set up during initialization; lookups
+// are safe for concurrent use afterwards.
+type EnumType[T comparable] struct {
+	byName map[string]T
+	names  map[T]string
+	// Canonical names, sorted, for error messages.
+	allowed []string
+}
+
+func NewEnumType[T comparable](values map[string]T) *EnumType[T] {
+	e := &EnumType[T]{
+		byName: make(map[string]T, len(values)),
+		names:  make(map[T]string, len(values)),
+	}
+	for name, val := range values {
+		e.addName(name, val)
+		if other, ok := e.names[val]; ok {
+			a, b := sortedPair(name, other)
+			panic(fmt.Sprintf("simplequery: enum names %q and %q have the same value", a, b))
+		}
+		e.names[val] = name
+		e.allowed = append(e.allowed, name)
+	}
+	sort.Strings(e.allowed)
+	return e
+}
+
+// addName panics if the name is already registered, ignoring case, since the
+// result of parsing it would be ambiguous.
+func (e *EnumType[T]) addName(name string, val T) {
+	key := strings.ToLower(name)
+	if _, ok := e.byName[key]; ok {
+		panic(fmt.Sprintf("simplequery: enum name %q is registered more than once", key))
+	}
+	e.byName[key] = val
+}
+
+func sortedPair(a, b string) (string, string) {
+	if a > b {
+		return b, a
+	}
+	return a, b
+}
+
+// Alias registers an additional name for val. Aliases are accepted when
+// parsing, but Format always returns the canonical name. Like NewEnumType, it
+// panics if the name is already registered, ignoring case.
+func (e *EnumType[T]) Alias(alias string, val T) *EnumType[T] {
+	e.addName(alias, val)
+	return e
+}
+
+func (e *EnumType[T]) Parse(str string) (T, error) {
+	if val, ok := e.byName[strings.ToLower(str)]; ok {
+		return val, nil
+	}
+	var zero T
+	return zero, unknownEnumErr(str, e.allowed)
+}
+
+// Format returns the canonical name of val, or false if val is not a member
+// of the enum.
+func (e *EnumType[T]) Format(val T) (string, bool) {
+	name, ok := e.names[val]
+	return name, ok
+}
+
+func (e *EnumType[T]) ParseValue(s *StringValue) (T, error) {
+	if s == nil {
+		var zero T
+		return zero, UnspecifiedValueErr
+	}
+	return e.Parse(string(*s))
+}
+
+func (e *EnumType[T]) Value(s *StringValue, def ...T) T {
+	var defVal T
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := e.ParseValue(s); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (e *EnumType[T]) Values(vs ValueSet) []T {
+	res := make([]T, len(vs))
+	for i := range vs {
+		res[i] = e.Value(&vs[i])
+	}
+	return res
+}
+
+func (e *EnumType[T]) ParseValues(vs ValueSet, mode ...PluralMode) ([]T, error) {
+	return parseValues(vs, e.ParseValue, mode...)
+}
+
+type enumFlag interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// ParseFlags parses a list of enum names, such as "read,write", into the
+// bitwise OR of their values. An empty value yields no flags.
+func ParseFlags[T enumFlag](e *EnumType[T], s *StringValue, sep ...string) (T, error) {
+	var res T
+	if s == nil {
+		return res, UnspecifiedValueErr
+	}
+	if *s == "" {
+		return res, nil
+	}
+	for _, name := range s.List(sep...) {
+		val, err := e.Parse(string(name))
+		if err != nil {
+			return 0, err
+		}
+		res |= val
+	}
+	return res, nil
+}
+
+// FormatFlags lists the names of the enum values set in flags, in
+// alphabetical order, joined with the separator (comma by default).
+func FormatFlags[T enumFlag](e *EnumType[T], flags T, sep ...string) string {
+	separator := ","
+	if len(sep) > 0 {
+		separator = sep[0]
+	}
+
+	names := []string{}
+	for val, name := range e.names {
+		if val != 0 && flags&val == val {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, separator)
+}
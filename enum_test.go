@@ -0,0 +1,199 @@
+package simplequery
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseEnum(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res string
+	var err error
+
+	res, err = ParseEnum("OPEN", "open", "closed")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal("open"))
+
+	res, err = ParseEnum("closed", "Open", "Closed")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal("Closed"))
+
+	_, err = ParseEnum("pending", "open", "closed")
+	Ω(err).ShouldNot(BeNil())
+	Ω(err.Error()).Should(Equal(`parsing enum "pending": expected one of open, closed`))
+
+	_, err = ParseEnum("")
+	Ω(err).ShouldNot(BeNil())
+}
+
+func TestStringValueEnum(t *testing.T) {
+	RegisterTestingT(t)
+
+	var v *StringValue
+	var err error
+
+	v = psv("Open")
+	Ω(v.Enum("open", "closed")).Should(Equal("open"))
+
+	v = psv("pending")
+	Ω(v.Enum("open", "closed")).Should(Equal(""))
+
+	v = nil
+	_, err = v.ParseEnum("open")
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	Ω(v.Enum("open")).Should(Equal(""))
+
+	vs := ValueSetFrom([]string{"OPEN", "x", "closed"})
+	Ω(vs.Enums("open", "closed")).Should(Equal([]string{"open", "", "closed"}))
+}
+
+type testStatus int
+
+const (
+	testStatusUnknown testStatus = iota
+	testStatusOpen
+	testStatusClosed
+)
+
+func (s *testStatus) UnmarshalText(text []byte) error {
+	val, err := testStatuses.Parse(string(text))
+	*s = val
+	return err
+}
+
+var testStatuses = NewEnumType(map[string]testStatus{
+	"open":   testStatusOpen,
+	"closed": testStatusClosed,
+}).Alias("done", testStatusClosed)
+
+func TestEnumType(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res testStatus
+	var err error
+	var name string
+	var ok bool
+
+	res, err = testStatuses.Parse("Open")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(testStatusOpen))
+
+	res, err = testStatuses.Parse("DONE")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(testStatusClosed))
+
+	_, err = testStatuses.Parse("pending")
+	Ω(err).ShouldNot(BeNil())
+	Ω(err.Error()).Should(Equal(`parsing enum "pending": expected one of closed, open`))
+
+	name, ok = testStatuses.Format(testStatusClosed)
+	Ω(ok).Should(BeTrue())
+	Ω(name).Should(Equal("closed"))
+
+	_, ok = testStatuses.Format(testStatusUnknown)
+	Ω(ok).Should(BeFalse())
+
+	Ω(testStatuses.Value(psv("closed"))).Should(Equal(testStatusClosed))
+	Ω(testStatuses.Value(psv("x"), testStatusOpen)).Should(Equal(testStatusOpen))
+	Ω(testStatuses.Value(nil)).Should(Equal(testStatusUnknown))
+	_, err = testStatuses.ParseValue(nil)
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+
+	vs := ValueSetFrom([]string{"open", "Done", "x"})
+	Ω(testStatuses.Values(vs)).Should(Equal([]testStatus{
+		testStatusOpen, testStatusClosed, testStatusUnknown,
+	}))
+}
+
+func TestStringValueParseText(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res testStatus
+	var err error
+
+	err = psv("done").ParseText(&res)
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(testStatusClosed))
+
+	err = psv("x").ParseText(&res)
+	Ω(err).ShouldNot(BeNil())
+
+	err = (*StringValue)(nil).ParseText(&res)
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+}
+
+type testPerm uint8
+
+const (
+	testPermRead testPerm = 1 << iota
+	testPermWrite
+	testPermExec
+)
+
+var testPerms = NewEnumType(map[string]testPerm{
+	"read":  testPermRead,
+	"write": testPermWrite,
+	"exec":  testPermExec,
+}).Alias("rw", testPermRead|testPermWrite)
+
+func TestParseFlags(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res testPerm
+	var err error
+
+	res, err = ParseFlags(testPerms, psv("read,Exec"))
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(testPermRead | testPermExec))
+
+	res, err = ParseFlags(testPerms, psv("rw|exec"), "|")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(testPermRead | testPermWrite | testPermExec))
+
+	res, err = ParseFlags(testPerms, psv(""))
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(testPerm(0)))
+
+	_, err = ParseFlags(testPerms, psv("read,delete"))
+	Ω(err).ShouldNot(BeNil())
+	var parseErr *ParseError
+	Ω(errors.As(err, &parseErr)).Should(BeTrue())
+	Ω(parseErr.Value).Should(Equal("delete"))
+
+	_, err = ParseFlags(testPerms, nil)
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+
+	Ω(FormatFlags(testPerms, testPermRead|testPermExec)).Should(Equal("exec,read"))
+	Ω(FormatFlags(testPerms, testPermWrite, "|")).Should(Equal("write"))
+	Ω(FormatFlags(testPerms, 0)).Should(Equal(""))
+
+	str := FormatFlags(testPerms, testPermRead|testPermWrite|testPermExec)
+	Ω(strings.Split(str, ",")).Should(HaveLen(3))
+	res, err = ParseFlags(testPerms, psv(str))
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(testPermRead | testPermWrite | testPermExec))
+}
+
+func TestNewEnumType_Collisions(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(func() {
+		NewEnumType(map[string]int{"c": 3, "C": 2})
+	}).Should(PanicWith(`simplequery: enum name "c" is registered more than once`))
+
+	Ω(func() {
+		NewEnumType(map[string]int{"open": 1, "opened": 1})
+	}).Should(PanicWith(`simplequery: enum names "open" and "opened" have the same value`))
+
+	Ω(func() {
+		NewEnumType(map[string]int{"open": 1, "closed": 2}).Alias("OPEN", 1)
+	}).Should(PanicWith(`simplequery: enum name "open" is registered more than once`))
+
+	Ω(func() {
+		NewEnumType(map[string]int{"open": 1}).Alias("active", 1).Alias("started", 1)
+	}).ShouldNot(Panic())
+}
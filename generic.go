@@ -0,0 +1,121 @@
+package simplequery
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	UnsupportedTypeErr = errors.New("No parser or formatter is registered for the type")
+	WrongTypeErr       = errors.New("The parser returned a value of a wrong type")
+	NilValueErr        = errors.New("The value to format is nil")
+)
+
+// Getter provides the values of a query to the generic accessors. It is
+// implemented by Q, Params, View, MemoQ, TrackedQ and Resolver.
+type Getter interface {
+	Get(key string) *StringValue
+	GetAll(key string) ValueSet
+}
+
+// RegisterParser makes fn the parser used by Parse, Get and GetAll for values
+// of type T. It replaces any parser previously registered for T in
+// DefaultRegistry, including the built-in ones.
+func RegisterParser[T any](fn func(string) (T, error)) {
+	Register(DefaultRegistry, fn, nil)
+}
+
+// RegisterFormatter makes fn the formatter used by Format for values of type
+// T. It replaces any formatter previously registered for T in
+// DefaultRegistry, including the built-in ones.
+func RegisterFormatter[T any](fn func(T) string) {
+	Register(DefaultRegistry, nil, fn)
+}
+
+// Parse converts the value to T using DefaultRegistry. All the types
+// supported by StringValue accessors are registered by default. Otherwise,
+// T or *T must implement encoding.TextUnmarshaler.
+func Parse[T any](s *StringValue) (T, error) {
+	return ParseWith[T](DefaultRegistry, s)
+}
+
+// ParseWith converts the value to T using the parsers of r.
+func ParseWith[T any](r *Registry, s *StringValue) (T, error) {
+	var res T
+	val, err := r.Parse(typeOf[T](), s)
+	if err != nil {
+		return res, err
+	}
+	res, ok := val.(T)
+	if !ok && val != nil {
+		return res, fmt.Errorf("%w: %T is not %v", WrongTypeErr, val, typeOf[T]())
+	}
+	// A nil val stands for the zero value of an interface or pointer T.
+	return res, nil
+}
+
+// Format converts val to its parameter representation using DefaultRegistry,
+// so that Parse[T] returns val back.
+func Format[T any](val T) (string, error) {
+	return FormatWith(DefaultRegistry, val)
+}
+
+// FormatWith converts val to its parameter representation using the
+// formatters of r.
+func FormatWith[T any](r *Registry, val T) (string, error) {
+	return r.Format(val)
+}
+
+// Get returns the first value of key converted to T, or the default value if
+// the key is missing or its value cannot be converted.
+func Get[T any](q Getter, key string, def ...T) T {
+	return GetWith(DefaultRegistry, q, key, def...)
+}
+
+// GetWith is like Get but uses the parsers of r.
+func GetWith[T any](r *Registry, q Getter, key string, def ...T) T {
+	var defVal T
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := ParseWith[T](r, q.Get(key)); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+// GetAll returns all the values of key converted to T. Values that cannot be
+// converted are replaced with the zero value of T.
+func GetAll[T any](q Getter, key string) []T {
+	return GetAllWith[T](DefaultRegistry, q, key)
+}
+
+// GetAllWith is like GetAll but uses the parsers of r.
+func GetAllWith[T any](r *Registry, q Getter, key string) []T {
+	vs := q.GetAll(key)
+	res := make([]T, len(vs))
+	for i := range vs {
+		res[i], _ = ParseWith[T](r, &vs[i])
+	}
+	return res
+}
+
+// ParseAll converts all the values to T. Unlike GetAll it does not replace
+// invalid values with zeros, see PluralMode.
+func ParseAll[T any](vs ValueSet, mode ...PluralMode) ([]T, error) {
+	return ParseAllWith[T](DefaultRegistry, vs, mode...)
+}
+
+// ParseAllWith is like ParseAll but uses the parsers of r.
+func ParseAllWith[T any](r *Registry, vs ValueSet, mode ...PluralMode) ([]T, error) {
+	return parseValues(vs, func(s *StringValue) (T, error) {
+		return ParseWith[T](r, s)
+	}, mode...)
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
@@ -0,0 +1,129 @@
+package simplequery
+
+import (
+	"errors"
+	"math/big"
+	"net/netip"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseGeneric_Builtin(t *testing.T) {
+	RegisterTestingT(t)
+
+	var err error
+
+	Ω(Parse[string](psv("abc"))).Should(Equal("abc"))
+	Ω(Parse[bool](psv("on"))).Should(BeTrue())
+	Ω(Parse[int](psv("-12"))).Should(Equal(-12))
+	Ω(Parse[uint8](psv("255"))).Should(Equal(uint8(255)))
+	Ω(Parse[float64](psv("1.5"))).Should(Equal(1.5))
+	Ω(Parse[time.Time](psv("123"))).Should(Equal(time.Unix(123, 0).UTC()))
+	Ω(Parse[time.Duration](psv("1m30s"))).Should(Equal(90 * time.Second))
+	Ω(Parse[Date](psv("2016-02-29"))).Should(Equal(Date{2016, time.February, 29}))
+	Ω(Parse[netip.Addr](psv("192.0.2.1"))).Should(Equal(netip.MustParseAddr("192.0.2.1")))
+	Ω(Parse[*big.Int](psv("12"))).Should(Equal(big.NewInt(12)))
+	Ω(Parse[Point](psv("1,2"))).Should(Equal(Point{1, 2}))
+
+	u, err := Parse[*url.URL](psv("https://example.com"))
+	Ω(err).Should(BeNil())
+	Ω(u.Host).Should(Equal("example.com"))
+
+	_, err = Parse[uint8](psv("256"))
+	Ω(err).ShouldNot(BeNil())
+
+	_, err = Parse[int]((*StringValue)(nil))
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+
+	_, err = Parse[struct{}](psv("x"))
+	Ω(errors.Is(err, UnsupportedTypeErr)).Should(BeTrue())
+}
+
+type testRegion string
+
+type testColor struct {
+	R, G, B uint8
+}
+
+func (c *testColor) UnmarshalText(text []byte) error {
+	b, err := ParseHex(string(text))
+	if err != nil || len(b) != 3 {
+		return errors.New("invalid color")
+	}
+	c.R, c.G, c.B = b[0], b[1], b[2]
+	return nil
+}
+
+func TestParseGeneric_TextUnmarshaler(t *testing.T) {
+	RegisterTestingT(t)
+
+	var err error
+
+	Ω(Parse[testColor](psv("ff8000"))).Should(Equal(testColor{255, 128, 0}))
+	Ω(Parse[*testColor](psv("ff8000"))).Should(Equal(&testColor{255, 128, 0}))
+	Ω(Parse[testStatus](psv("done"))).Should(Equal(testStatusClosed))
+
+	_, err = Parse[testColor](psv("red"))
+	Ω(err).ShouldNot(BeNil())
+
+	_, err = Parse[*testColor](psv("red"))
+	Ω(err).ShouldNot(BeNil())
+
+	_, err = Parse[testColor](nil)
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+}
+
+func TestParseGeneric_Registered(t *testing.T) {
+	RegisterTestingT(t)
+
+	var err error
+
+	// Register on a copy of the default registry and restore it, so other
+	// tests do not see the parser.
+	saved := DefaultRegistry
+	DefaultRegistry = NewRegistry()
+	defer func() { DefaultRegistry = saved }()
+
+	RegisterParser(func(str string) (testRegion, error) {
+		switch str = strings.ToLower(str); str {
+		case "us", "eu":
+			return testRegion(str), nil
+		}
+		return "", errors.New("unknown region")
+	})
+
+	Ω(Parse[testRegion](psv("EU"))).Should(Equal(testRegion("eu")))
+
+	_, err = Parse[testRegion](psv("mars"))
+	Ω(err).Should(MatchError("unknown region"))
+
+	_, err = Parse[testRegion](nil)
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+
+	_, err = ParseWith[testRegion](saved, psv("EU"))
+	Ω(errors.Is(err, UnsupportedTypeErr)).Should(BeTrue())
+}
+
+func TestGetGeneric(t *testing.T) {
+	RegisterTestingT(t)
+
+	q := FromQuery(map[string][]string{
+		"limit": {"10", "x", "30"},
+		"at":    {"2016-02-03"},
+	})
+
+	Ω(Get[int](q, "limit")).Should(Equal(10))
+	Ω(Get[int](q, "limit", 20)).Should(Equal(10))
+	Ω(Get[int](q, "offset")).Should(Equal(0))
+	Ω(Get[int](q, "offset", 20)).Should(Equal(20))
+	Ω(Get[Date](q, "at")).Should(Equal(Date{2016, time.February, 3}))
+	Ω(Get[int](q, "at", -1)).Should(Equal(-1))
+
+	Ω(GetAll[int](q, "limit")).Should(Equal([]int{10, 0, 30}))
+	Ω(GetAll[string](q, "limit")).Should(Equal([]string{"10", "x", "30"}))
+	Ω(GetAll[int](q, "offset")).Should(Equal([]int{}))
+}
@@ -0,0 +1,282 @@
+package simplequery
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Point is a geographic location in degrees.
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+// ParsePoint parses a point given as "lat,lng", e.g. "43.65,-79.38".
+func ParsePoint(str string) (Point, error) {
+	parts := strings.Split(str, ",")
+	if len(parts) != 2 {
+		return Point{}, &ParseError{"point", str, errors.New("expected lat,lng")}
+	}
+
+	lat, err := parseCoord(parts[0], 90)
+	if err != nil {
+		return Point{}, &ParseError{"point", str, fmt.Errorf("latitude: %v", err)}
+	}
+	lng, err := parseCoord(parts[1], 180)
+	if err != nil {
+		return Point{}, &ParseError{"point", str, fmt.Errorf("longitude: %v", err)}
+	}
+	return Point{Lat: lat, Lng: lng}, nil
+}
+
+func (p Point) String() string {
+	return formatCoord(p.Lat) + "," + formatCoord(p.Lng)
+}
+
+// BBox is a bounding box in degrees. A box with MinLon greater than MaxLon
+// crosses the antimeridian, e.g. {170, -10, -170, 10} spans 20 degrees of
+// longitude around 180.
+type BBox struct {
+	MinLon float64
+	MinLat float64
+	MaxLon float64
+	MaxLat float64
+}
+
+// ParseBBox parses a bounding box given as "minLon,minLat,maxLon,maxLat", the
+// order used by GeoJSON and OGC APIs.
+func ParseBBox(str string) (BBox, error) {
+	parts := strings.Split(str, ",")
+	if len(parts) != 4 {
+		return BBox{}, &ParseError{"bbox", str, errors.New("expected minLon,minLat,maxLon,maxLat")}
+	}
+
+	var coords [4]float64
+	for i, part := range parts {
+		limit := 180.0
+		if i%2 == 1 {
+			limit = 90
+		}
+		val, err := parseCoord(part, limit)
+		if err != nil {
+			return BBox{}, &ParseError{"bbox", str, err}
+		}
+		coords[i] = val
+	}
+
+	box := BBox{coords[0], coords[1], coords[2], coords[3]}
+	if box.MinLat > box.MaxLat {
+		return BBox{}, &ParseError{"bbox", str, errors.New("minLat is greater than maxLat")}
+	}
+	return box, nil
+}
+
+func (b BBox) String() string {
+	return formatCoord(b.MinLon) + "," + formatCoord(b.MinLat) + "," +
+		formatCoord(b.MaxLon) + "," + formatCoord(b.MaxLat)
+}
+
+func (b BBox) CrossesAntimeridian() bool {
+	return b.MinLon > b.MaxLon
+}
+
+func (b BBox) Contains(p Point) bool {
+	if p.Lat < b.MinLat || p.Lat > b.MaxLat {
+		return false
+	}
+	if b.CrossesAntimeridian() {
+		return p.Lng >= b.MinLon || p.Lng <= b.MaxLon
+	}
+	return p.Lng >= b.MinLon && p.Lng <= b.MaxLon
+}
+
+// Center returns the middle point of the box, taking antimeridian crossing
+// into account.
+func (b BBox) Center() Point {
+	maxLon := b.MaxLon
+	if b.CrossesAntimeridian() {
+		maxLon += 360
+	}
+	lng := (b.MinLon + maxLon) / 2
+	if lng > 180 {
+		lng -= 360
+	}
+	return Point{Lat: (b.MinLat + b.MaxLat) / 2, Lng: lng}
+}
+
+const (
+	geohashAlphabet  = "0123456789bcdefghjkmnpqrstuvwxyz"
+	maxGeohashLength = 12
+)
+
+// ParseGeohash decodes a geohash, e.g. "dpz83", into the cell it denotes.
+// Geohashes are case-insensitive and limited to 12 characters, which is
+// already finer than any practical precision.
+func ParseGeohash(str string) (BBox, error) {
+	if str == "" || len(str) > maxGeohashLength {
+		return BBox{}, &ParseError{"geohash", str, errors.New("invalid length")}
+	}
+
+	box := BBox{MinLon: -180, MinLat: -90, MaxLon: 180, MaxLat: 90}
+	even := true
+	for _, c := range strings.ToLower(str) {
+		idx := strings.IndexRune(geohashAlphabet, c)
+		if idx < 0 {
+			return BBox{}, &ParseError{"geohash", str, fmt.Errorf("invalid character %q", c)}
+		}
+		for bit := 4; bit >= 0; bit-- {
+			set := idx&(1<<uint(bit)) != 0
+			if even {
+				mid := (box.MinLon + box.MaxLon) / 2
+				if set {
+					box.MinLon = mid
+				} else {
+					box.MaxLon = mid
+				}
+			} else {
+				mid := (box.MinLat + box.MaxLat) / 2
+				if set {
+					box.MinLat = mid
+				} else {
+					box.MaxLat = mid
+				}
+			}
+			even = !even
+		}
+	}
+	return box, nil
+}
+
+var distanceUnits = map[string]float64{
+	"":   1,
+	"m":  1,
+	"km": 1000,
+	"mi": 1609.344,
+	"ft": 0.3048,
+	"yd": 0.9144,
+	"nm": 1852,
+}
+
+// ParseDistance parses a non-negative distance, e.g. "5km" or "250 m", and
+// returns it in meters. The supported units are m (default), km, mi, ft, yd
+// and nm (nautical miles).
+func ParseDistance(str string) (float64, error) {
+	num, suffix := splitNumberSuffix(str)
+	factor, ok := distanceUnits[strings.ToLower(suffix)]
+	if !ok {
+		return 0, &ParseError{"distance", str, fmt.Errorf("unknown unit %q", suffix)}
+	}
+	if checkDecimalNumber(num, false, false) != nil {
+		return 0, &ParseError{"distance", str, InvalidNumberErr}
+	}
+	val, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, &ParseError{"distance", str, err}
+	}
+	if val < 0 {
+		return 0, &ParseError{"distance", str, OutOfRangeErr}
+	}
+	return val * factor, nil
+}
+
+// parseCoord parses a decimal coordinate in degrees within [-limit, limit].
+func parseCoord(str string, limit float64) (float64, error) {
+	str = strings.TrimSpace(str)
+	if checkDecimalNumber(str, false, false) != nil {
+		return 0, InvalidNumberErr
+	}
+	val, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, err
+	}
+	if val < -limit || val > limit {
+		return 0, OutOfRangeErr
+	}
+	return val, nil
+}
+
+func formatCoord(val float64) string {
+	return strconv.FormatFloat(val, 'f', -1, 64)
+}
+
+func (s *StringValue) ParsePoint() (Point, error) {
+	if s == nil {
+		return Point{}, UnspecifiedValueErr
+	}
+	return ParsePoint(string(*s))
+}
+
+func (s *StringValue) Point(def ...Point) Point {
+	defVal := Point{}
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParsePoint(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseBBox() (BBox, error) {
+	if s == nil {
+		return BBox{}, UnspecifiedValueErr
+	}
+	return ParseBBox(string(*s))
+}
+
+func (s *StringValue) BBox(def ...BBox) BBox {
+	defVal := BBox{}
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseBBox(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseGeohash() (BBox, error) {
+	if s == nil {
+		return BBox{}, UnspecifiedValueErr
+	}
+	return ParseGeohash(string(*s))
+}
+
+func (s *StringValue) Geohash(def ...BBox) BBox {
+	defVal := BBox{}
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseGeohash(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseDistance() (float64, error) {
+	if s == nil {
+		return 0, UnspecifiedValueErr
+	}
+	return ParseDistance(string(*s))
+}
+
+func (s *StringValue) Distance(def ...float64) float64 {
+	defVal := float64(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseDistance(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
@@ -0,0 +1,164 @@
+package simplequery
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParsePoint(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res Point
+	var err error
+
+	res, err = ParsePoint("43.65,-79.38")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(Point{Lat: 43.65, Lng: -79.38}))
+	Ω(res.String()).Should(Equal("43.65,-79.38"))
+
+	res, err = ParsePoint("-90, 180")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(Point{Lat: -90, Lng: 180}))
+
+	for _, str := range []string{"", "43.65", "43.65,-79.38,1", "91,0", "0,-180.5", "NaN,0", "1e1,0", "a,b"} {
+		_, err = ParsePoint(str)
+		Ω(err).ShouldNot(BeNil(), str)
+		Ω(err.(*ParseError).Kind).Should(Equal("point"))
+	}
+
+	_, err = ParsePoint("91,0")
+	Ω(err.Error()).Should(Equal(`parsing point "91,0": latitude: ` + OutOfRangeErr.Error()))
+}
+
+func TestParseBBox(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res BBox
+	var err error
+
+	res, err = ParseBBox("-79.5,43.5,-79.2,43.8")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(BBox{-79.5, 43.5, -79.2, 43.8}))
+	Ω(res.String()).Should(Equal("-79.5,43.5,-79.2,43.8"))
+	Ω(res.CrossesAntimeridian()).Should(BeFalse())
+	Ω(res.Contains(Point{43.65, -79.38})).Should(BeTrue())
+	Ω(res.Contains(Point{43.65, -80})).Should(BeFalse())
+	Ω(res.Contains(Point{44, -79.38})).Should(BeFalse())
+	Ω(res.Center()).Should(Equal(Point{43.65, -79.35}))
+
+	res, err = ParseBBox("170,-10,-170,10")
+	Ω(err).Should(BeNil())
+	Ω(res.CrossesAntimeridian()).Should(BeTrue())
+	Ω(res.Contains(Point{0, 175})).Should(BeTrue())
+	Ω(res.Contains(Point{0, -175})).Should(BeTrue())
+	Ω(res.Contains(Point{0, 180})).Should(BeTrue())
+	Ω(res.Contains(Point{0, 0})).Should(BeFalse())
+	Ω(res.Center()).Should(Equal(Point{0, 180}))
+
+	res, err = ParseBBox("160,-10,-170,10")
+	Ω(err).Should(BeNil())
+	Ω(res.Center()).Should(Equal(Point{0, 175}))
+
+	res, err = ParseBBox("170,-10,-150,10")
+	Ω(err).Should(BeNil())
+	Ω(res.Center()).Should(Equal(Point{0, -170}))
+
+	for _, str := range []string{"", "1,2,3", "1,2,3,4,5", "0,10,1,5", "0,-91,1,0", "-181,0,0,1", "a,0,1,1"} {
+		_, err = ParseBBox(str)
+		Ω(err).ShouldNot(BeNil(), str)
+		Ω(err.(*ParseError).Kind).Should(Equal("bbox"))
+	}
+}
+
+func TestParseGeohash(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res BBox
+	var err error
+
+	res, err = ParseGeohash("dpz83")
+	Ω(err).Should(BeNil())
+	Ω(res.Contains(Point{43.65, -79.38})).Should(BeTrue())
+	Ω(res.MinLat).Should(BeNumerically("~", 43.6376953125, 1e-9))
+	Ω(res.MaxLat).Should(BeNumerically("~", 43.681640625, 1e-9))
+	Ω(res.MinLon).Should(BeNumerically("~", -79.4091796875, 1e-9))
+	Ω(res.MaxLon).Should(BeNumerically("~", -79.365234375, 1e-9))
+
+	upper, err := ParseGeohash("DPZ83")
+	Ω(err).Should(BeNil())
+	Ω(upper).Should(Equal(res))
+
+	res, err = ParseGeohash("s")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(BBox{0, 0, 45, 45}))
+
+	for _, str := range []string{"", "dpz8a", "dpz8i", "dpz83dpz83dpz", "dp z"} {
+		_, err = ParseGeohash(str)
+		Ω(err).ShouldNot(BeNil(), str)
+		Ω(err.(*ParseError).Kind).Should(Equal("geohash"))
+	}
+}
+
+func TestParseDistance(t *testing.T) {
+	RegisterTestingT(t)
+
+	cases := map[string]float64{
+		"0":      0,
+		"250":    250,
+		"250m":   250,
+		"250 m":  250,
+		"5km":    5000,
+		"5KM":    5000,
+		"1.5km":  1500,
+		"1mi":    1609.344,
+		"100ft":  30.48,
+		"10yd":   9.144,
+		"2nm":    3704,
+		".5 km ": 500,
+	}
+	for str, exp := range cases {
+		res, err := ParseDistance(str)
+		Ω(err).Should(BeNil(), str)
+		Ω(res).Should(BeNumerically("~", exp, 1e-9), str)
+	}
+
+	for _, str := range []string{"", "km", "5 light years", "-5km", "5kmm", "1e3m", "Inf"} {
+		_, err := ParseDistance(str)
+		Ω(err).ShouldNot(BeNil(), str)
+		Ω(err.(*ParseError).Kind).Should(Equal("distance"))
+	}
+}
+
+func TestStringValueGeo(t *testing.T) {
+	RegisterTestingT(t)
+
+	var v *StringValue
+	var err error
+
+	v = psv("43.65,-79.38")
+	Ω(v.Point()).Should(Equal(Point{43.65, -79.38}))
+	Ω(v.BBox(BBox{1, 2, 3, 4})).Should(Equal(BBox{1, 2, 3, 4}))
+
+	v = psv("-79.5,43.5,-79.2,43.8")
+	Ω(v.BBox()).Should(Equal(BBox{-79.5, 43.5, -79.2, 43.8}))
+	Ω(v.Point(Point{1, 2})).Should(Equal(Point{1, 2}))
+
+	v = psv("s")
+	Ω(v.Geohash()).Should(Equal(BBox{0, 0, 45, 45}))
+
+	v = psv("5 km")
+	Ω(v.Distance()).Should(Equal(float64(5000)))
+	Ω(v.Geohash()).Should(Equal(BBox{}))
+
+	v = nil
+	_, err = v.ParsePoint()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	_, err = v.ParseBBox()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	_, err = v.ParseGeohash()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	_, err = v.ParseDistance()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	Ω(v.Distance(1000)).Should(Equal(float64(1000)))
+}
@@ -0,0 +1,12 @@
+module github.com/PlanitarInc/go-simplequery
+
+go 1.18
+
+require github.com/onsi/gomega v1.27.10
+
+require (
+	github.com/google/go-cmp v0.5.9 // indirect
+	golang.org/x/net v0.12.0 // indirect
+	golang.org/x/text v0.13.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,387 @@
+package simplequery
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// ParseError describes a value that could not be parsed as the requested
+// kind of identifier.
+type ParseError struct {
+	// Kind names the expected format, e.g. "UUID" or "email".
+	Kind string
+	// Value is the offending value.
+	Value string
+	// Err is the reason the value was rejected.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parsing %s %q: %v", e.Kind, e.Value, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	invalidFormatErr = errors.New("invalid format")
+)
+
+// UUID is a 128-bit universally unique identifier.
+type UUID [16]byte
+
+// ParseUUID parses a UUID in the canonical "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+// format. Hex digits may be in either case.
+func ParseUUID(str string) (UUID, error) {
+	var res UUID
+	if len(str) != 36 || str[8] != '-' || str[13] != '-' || str[18] != '-' || str[23] != '-' {
+		return res, &ParseError{"UUID", str, invalidFormatErr}
+	}
+
+	digits := str[0:8] + str[9:13] + str[14:18] + str[19:23] + str[24:36]
+	if _, err := hex.Decode(res[:], []byte(digits)); err != nil {
+		return UUID{}, &ParseError{"UUID", str, err}
+	}
+	return res, nil
+}
+
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+func (u UUID) IsZero() bool {
+	return u == UUID{}
+}
+
+// ParseEmail parses a bare email address such as "user@example.com". Display
+// names and angle brackets ("User <user@example.com>") are rejected.
+func ParseEmail(str string) (string, error) {
+	addr, err := mail.ParseAddress(str)
+	if err != nil {
+		return "", &ParseError{"email", str, err}
+	}
+	if addr.Name != "" || addr.Address != str {
+		return "", &ParseError{"email", str, invalidFormatErr}
+	}
+	return addr.Address, nil
+}
+
+// ParseIP parses an IPv4 or IPv6 address. IPv6 zones are rejected.
+func ParseIP(str string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(str)
+	if err != nil {
+		return netip.Addr{}, &ParseError{"IP address", str, err}
+	}
+	if addr.Zone() != "" {
+		return netip.Addr{}, &ParseError{"IP address", str, errors.New("unexpected zone")}
+	}
+	return addr, nil
+}
+
+// ParsePrefix parses an IP network in CIDR notation, e.g. "10.0.0.0/8".
+func ParsePrefix(str string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(str)
+	if err != nil {
+		return netip.Prefix{}, &ParseError{"CIDR prefix", str, err}
+	}
+	return prefix, nil
+}
+
+// ParseMAC parses a hardware address in any format accepted by
+// net.ParseMAC, e.g. "00:00:5e:00:53:01".
+func ParseMAC(str string) (net.HardwareAddr, error) {
+	mac, err := net.ParseMAC(str)
+	if err != nil {
+		return nil, &ParseError{"MAC address", str, err}
+	}
+	return mac, nil
+}
+
+const (
+	maxHostnameLen = 253
+	maxLabelLen    = 63
+)
+
+// ParseHostname checks that str is a valid DNS host name as defined by
+// RFC 1123 and returns it in lower case without the trailing dot. Names with a
+// numeric last label, such as IPv4 addresses, are rejected.
+func ParseHostname(str string) (string, error) {
+	host := strings.TrimSuffix(str, ".")
+	if host == "" || len(host) > maxHostnameLen {
+		return "", &ParseError{"hostname", str, errors.New("invalid length")}
+	}
+
+	labels := strings.Split(host, ".")
+	if isDecimalInt(labels[len(labels)-1], false) {
+		// Rules out IPv4 addresses, top-level domains are never numeric.
+		return "", &ParseError{"hostname", str, errors.New("numeric top-level label")}
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > maxLabelLen {
+			return "", &ParseError{"hostname", str, errors.New("invalid label length")}
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return "", &ParseError{"hostname", str, errors.New("label starts or ends with a hyphen")}
+		}
+		for i := range label {
+			c := label[i]
+			if !isDigit(c) && c != '-' && !('a' <= c && c <= 'z') && !('A' <= c && c <= 'Z') {
+				return "", &ParseError{"hostname", str, fmt.Errorf("invalid character %q", c)}
+			}
+		}
+	}
+	return strings.ToLower(host), nil
+}
+
+// ParseAbsURL parses an absolute URL, i.e. one with both a scheme and a host.
+func ParseAbsURL(str string) (*url.URL, error) {
+	u, err := url.Parse(str)
+	if err != nil {
+		return nil, &ParseError{"URL", str, err}
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, &ParseError{"URL", str, errors.New("not an absolute URL")}
+	}
+	return u, nil
+}
+
+func (s *StringValue) ParseUUID() (UUID, error) {
+	if s == nil {
+		return UUID{}, UnspecifiedValueErr
+	}
+	return ParseUUID(string(*s))
+}
+
+func (s *StringValue) UUID(def ...UUID) UUID {
+	defVal := UUID{}
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseUUID(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseEmail() (string, error) {
+	if s == nil {
+		return "", UnspecifiedValueErr
+	}
+	return ParseEmail(string(*s))
+}
+
+func (s *StringValue) Email(def ...string) string {
+	defVal := ""
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseEmail(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseIP() (netip.Addr, error) {
+	if s == nil {
+		return netip.Addr{}, UnspecifiedValueErr
+	}
+	return ParseIP(string(*s))
+}
+
+func (s *StringValue) IP(def ...netip.Addr) netip.Addr {
+	defVal := netip.Addr{}
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseIP(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParsePrefix() (netip.Prefix, error) {
+	if s == nil {
+		return netip.Prefix{}, UnspecifiedValueErr
+	}
+	return ParsePrefix(string(*s))
+}
+
+func (s *StringValue) Prefix(def ...netip.Prefix) netip.Prefix {
+	defVal := netip.Prefix{}
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParsePrefix(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseMAC() (net.HardwareAddr, error) {
+	if s == nil {
+		return nil, UnspecifiedValueErr
+	}
+	return ParseMAC(string(*s))
+}
+
+func (s *StringValue) MAC(def ...net.HardwareAddr) net.HardwareAddr {
+	var defVal net.HardwareAddr
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseMAC(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseHostname() (string, error) {
+	if s == nil {
+		return "", UnspecifiedValueErr
+	}
+	return ParseHostname(string(*s))
+}
+
+func (s *StringValue) Hostname(def ...string) string {
+	defVal := ""
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseHostname(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseURL() (*url.URL, error) {
+	if s == nil {
+		return nil, UnspecifiedValueErr
+	}
+	return ParseAbsURL(string(*s))
+}
+
+func (s *StringValue) URL(def ...*url.URL) *url.URL {
+	var defVal *url.URL
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseURL(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s ValueSet) UUIDs() []UUID {
+	res := make([]UUID, len(s))
+	for i := range s {
+		res[i] = s[i].UUID()
+	}
+	return res
+}
+
+func (s ValueSet) ParseUUIDs(mode ...PluralMode) ([]UUID, error) {
+	return parseValues(s, (*StringValue).ParseUUID, mode...)
+}
+
+func (s ValueSet) Emails() []string {
+	res := make([]string, len(s))
+	for i := range s {
+		res[i] = s[i].Email()
+	}
+	return res
+}
+
+func (s ValueSet) ParseEmails(mode ...PluralMode) ([]string, error) {
+	return parseValues(s, (*StringValue).ParseEmail, mode...)
+}
+
+func (s ValueSet) IPs() []netip.Addr {
+	res := make([]netip.Addr, len(s))
+	for i := range s {
+		res[i] = s[i].IP()
+	}
+	return res
+}
+
+func (s ValueSet) ParseIPs(mode ...PluralMode) ([]netip.Addr, error) {
+	return parseValues(s, (*StringValue).ParseIP, mode...)
+}
+
+func (s ValueSet) Prefixes() []netip.Prefix {
+	res := make([]netip.Prefix, len(s))
+	for i := range s {
+		res[i] = s[i].Prefix()
+	}
+	return res
+}
+
+func (s ValueSet) ParsePrefixes(mode ...PluralMode) ([]netip.Prefix, error) {
+	return parseValues(s, (*StringValue).ParsePrefix, mode...)
+}
+
+func (s ValueSet) MACs() []net.HardwareAddr {
+	res := make([]net.HardwareAddr, len(s))
+	for i := range s {
+		res[i] = s[i].MAC()
+	}
+	return res
+}
+
+func (s ValueSet) ParseMACs(mode ...PluralMode) ([]net.HardwareAddr, error) {
+	return parseValues(s, (*StringValue).ParseMAC, mode...)
+}
+
+func (s ValueSet) Hostnames() []string {
+	res := make([]string, len(s))
+	for i := range s {
+		res[i] = s[i].Hostname()
+	}
+	return res
+}
+
+func (s ValueSet) ParseHostnames(mode ...PluralMode) ([]string, error) {
+	return parseValues(s, (*StringValue).ParseHostname, mode...)
+}
+
+func (s ValueSet) URLs() []*url.URL {
+	res := make([]*url.URL, len(s))
+	for i := range s {
+		res[i] = s[i].URL()
+	}
+	return res
+}
+
+func (s ValueSet) ParseURLs(mode ...PluralMode) ([]*url.URL, error) {
+	return parseValues(s, (*StringValue).ParseURL, mode...)
+}
@@ -0,0 +1,225 @@
+package simplequery
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"net/url"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseUUID(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res UUID
+	var err error
+
+	res, err = ParseUUID("123e4567-e89b-12d3-A456-426614174000")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(UUID{
+		0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3,
+		0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00,
+	}))
+	Ω(res.String()).Should(Equal("123e4567-e89b-12d3-a456-426614174000"))
+	Ω(res.IsZero()).Should(BeFalse())
+
+	for _, str := range []string{
+		"",
+		"123e4567e89b12d3a456426614174000",
+		"123e4567-e89b-12d3-a456-42661417400",
+		"123e4567-e89b-12d3-a456-42661417400g",
+		"{123e4567-e89b-12d3-a456-426614174000}",
+	} {
+		_, err = ParseUUID(str)
+		Ω(err).ShouldNot(BeNil(), str)
+
+		var parseErr *ParseError
+		Ω(errors.As(err, &parseErr)).Should(BeTrue())
+		Ω(parseErr.Kind).Should(Equal("UUID"))
+		Ω(parseErr.Value).Should(Equal(str))
+		Ω(err.Error()).Should(HavePrefix("parsing UUID "))
+	}
+}
+
+func TestParseEmail(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res string
+	var err error
+
+	res, err = ParseEmail("user+tag@example.com")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal("user+tag@example.com"))
+
+	for _, str := range []string{"", "user", "@example.com", "User <user@example.com>", " user@example.com"} {
+		_, err = ParseEmail(str)
+		Ω(err).ShouldNot(BeNil(), str)
+		Ω(err.(*ParseError).Kind).Should(Equal("email"))
+	}
+}
+
+func TestParseIP(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res netip.Addr
+	var err error
+
+	res, err = ParseIP("192.0.2.1")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(netip.MustParseAddr("192.0.2.1")))
+
+	res, err = ParseIP("2001:db8::1")
+	Ω(err).Should(BeNil())
+	Ω(res.Is6()).Should(BeTrue())
+
+	for _, str := range []string{"", "192.0.2", "192.0.2.256", "fe80::1%eth0", "example.com"} {
+		_, err = ParseIP(str)
+		Ω(err).ShouldNot(BeNil(), str)
+		Ω(err.(*ParseError).Kind).Should(Equal("IP address"))
+	}
+}
+
+func TestParsePrefix(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res netip.Prefix
+	var err error
+
+	res, err = ParsePrefix("10.0.0.0/8")
+	Ω(err).Should(BeNil())
+	Ω(res.Contains(netip.MustParseAddr("10.1.2.3"))).Should(BeTrue())
+
+	for _, str := range []string{"", "10.0.0.0", "10.0.0.0/33", "2001:db8::/129"} {
+		_, err = ParsePrefix(str)
+		Ω(err).ShouldNot(BeNil(), str)
+		Ω(err.(*ParseError).Kind).Should(Equal("CIDR prefix"))
+	}
+}
+
+func TestParseMAC(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res net.HardwareAddr
+	var err error
+
+	res, err = ParseMAC("00:00:5E:00:53:01")
+	Ω(err).Should(BeNil())
+	Ω(res.String()).Should(Equal("00:00:5e:00:53:01"))
+
+	res, err = ParseMAC("0000.5e00.5301")
+	Ω(err).Should(BeNil())
+	Ω(res.String()).Should(Equal("00:00:5e:00:53:01"))
+
+	_, err = ParseMAC("00:00:5e:00:53")
+	Ω(err).ShouldNot(BeNil())
+	Ω(err.(*ParseError).Kind).Should(Equal("MAC address"))
+}
+
+func TestParseHostname(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res string
+	var err error
+
+	res, err = ParseHostname("API-1.Example.com.")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal("api-1.example.com"))
+
+	res, err = ParseHostname("localhost")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal("localhost"))
+
+	long := ""
+	for i := 0; i < 64; i++ {
+		long += "a"
+	}
+	for _, str := range []string{"", ".", "a..b", "-a.com", "a-.com", "a_b.com", "a b", long + ".com", "192.0.2.1", "a.123"} {
+		_, err = ParseHostname(str)
+		Ω(err).ShouldNot(BeNil(), str)
+		Ω(err.(*ParseError).Kind).Should(Equal("hostname"))
+	}
+}
+
+func TestParseAbsURL(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res *url.URL
+	var err error
+
+	res, err = ParseAbsURL("https://example.com/a?b=c")
+	Ω(err).Should(BeNil())
+	Ω(res.Host).Should(Equal("example.com"))
+	Ω(res.Path).Should(Equal("/a"))
+
+	for _, str := range []string{"", "/a/b", "example.com", "mailto:user@example.com", "http://[::1"} {
+		_, err = ParseAbsURL(str)
+		Ω(err).ShouldNot(BeNil(), str)
+		Ω(err.(*ParseError).Kind).Should(Equal("URL"))
+	}
+}
+
+func TestStringValueIdentifiers(t *testing.T) {
+	RegisterTestingT(t)
+
+	var v *StringValue
+	var err error
+
+	v = psv("123e4567-e89b-12d3-a456-426614174000")
+	Ω(v.UUID().String()).Should(Equal("123e4567-e89b-12d3-a456-426614174000"))
+	Ω(v.Email("x")).Should(Equal("x"))
+
+	v = psv("user@example.com")
+	Ω(v.Email()).Should(Equal("user@example.com"))
+	Ω(v.UUID()).Should(Equal(UUID{}))
+
+	v = psv("192.0.2.1")
+	Ω(v.IP()).Should(Equal(netip.MustParseAddr("192.0.2.1")))
+	Ω(v.Hostname("x")).Should(Equal("x"))
+
+	v = psv("192.0.2.0/24")
+	Ω(v.Prefix()).Should(Equal(netip.MustParsePrefix("192.0.2.0/24")))
+	Ω(v.IP(netip.IPv6Unspecified())).Should(Equal(netip.IPv6Unspecified()))
+
+	v = psv("00:00:5e:00:53:01")
+	Ω(v.MAC().String()).Should(Equal("00:00:5e:00:53:01"))
+
+	v = psv("Example.com")
+	Ω(v.Hostname()).Should(Equal("example.com"))
+	Ω(v.URL()).Should(BeNil())
+
+	v = psv("https://example.com")
+	Ω(v.URL().String()).Should(Equal("https://example.com"))
+	Ω(v.MAC()).Should(BeNil())
+
+	v = nil
+	_, err = v.ParseUUID()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	_, err = v.ParseEmail()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	_, err = v.ParseIP()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	_, err = v.ParsePrefix()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	_, err = v.ParseMAC()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	_, err = v.ParseHostname()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	_, err = v.ParseURL()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	Ω(v.Hostname("localhost")).Should(Equal("localhost"))
+}
+
+func TestValueSetIdentifiers(t *testing.T) {
+	RegisterTestingT(t)
+
+	vs := ValueSetFrom([]string{"192.0.2.1", "x"})
+	Ω(vs.IPs()).Should(Equal([]netip.Addr{netip.MustParseAddr("192.0.2.1"), {}}))
+	Ω(vs.Hostnames()).Should(Equal([]string{"", "x"}))
+	Ω(vs.Prefixes()).Should(Equal([]netip.Prefix{{}, {}}))
+	Ω(vs.UUIDs()).Should(Equal([]UUID{{}, {}}))
+	Ω(vs.Emails()).Should(Equal([]string{"", ""}))
+	Ω(vs.MACs()).Should(Equal([]net.HardwareAddr{nil, nil}))
+	Ω(vs.URLs()).Should(Equal([]*url.URL{nil, nil}))
+}
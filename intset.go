@@ -0,0 +1,190 @@
+package simplequery
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MaxIntSetSize limits the number of integers a single IntSet value may
+// denote, so that values like "1-999999999" are rejected with
+// TooManyItemsErr. 0 means no limit.
+var MaxIntSetSize int64 = 10000
+
+// IntRange is an inclusive range of integers.
+type IntRange struct {
+	Start int64
+	End   int64
+}
+
+// Len returns the number of integers in the range, capped at
+// math.MaxInt64.
+func (r IntRange) Len() int64 {
+	if n := r.End - r.Start; n >= 0 && n < math.MaxInt64 {
+		return n + 1
+	}
+	return math.MaxInt64
+}
+
+// IntSet is a set of non-negative integers stored as sorted, non-adjacent
+// ranges.
+type IntSet struct {
+	ranges []IntRange
+}
+
+// ParseIntSet parses a comma-separated list of integers and inclusive
+// ranges, e.g. "1-5,8,10-12". Overlapping and adjacent ranges are merged.
+func ParseIntSet(str string) (IntSet, error) {
+	ranges := []IntRange{}
+	for _, item := range strings.Split(str, ",") {
+		r, err := parseIntRange(strings.TrimSpace(item))
+		if err != nil {
+			return IntSet{}, &ParseError{"integer set", str, err}
+		}
+		ranges = append(ranges, r)
+	}
+
+	set := NewIntSet(ranges...)
+	if MaxIntSetSize > 0 && set.Len() > MaxIntSetSize {
+		return IntSet{}, TooManyItemsErr
+	}
+	return set, nil
+}
+
+func parseIntRange(str string) (IntRange, error) {
+	first, last, isRange := strings.Cut(str, "-")
+	start, err := parseIntSetItem(first)
+	if err != nil {
+		return IntRange{}, err
+	}
+	if !isRange {
+		return IntRange{start, start}, nil
+	}
+	end, err := parseIntSetItem(last)
+	if err != nil {
+		return IntRange{}, err
+	}
+	if start > end {
+		return IntRange{}, fmt.Errorf("invalid range %q", str)
+	}
+	return IntRange{start, end}, nil
+}
+
+func parseIntSetItem(str string) (int64, error) {
+	str = strings.TrimSpace(str)
+	if !isDecimalInt(str, false) {
+		return 0, errors.New("expected integers and ranges like 1-5")
+	}
+	return strconv.ParseInt(str, 10, 64)
+}
+
+// NewIntSet returns the union of the ranges. Ranges with Start greater than
+// End are ignored.
+func NewIntSet(ranges ...IntRange) IntSet {
+	sorted := make([]IntRange, 0, len(ranges))
+	for _, r := range ranges {
+		if r.Start <= r.End {
+			sorted = append(sorted, r)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start < sorted[j].Start
+	})
+
+	res := []IntRange{}
+	for _, r := range sorted {
+		if n := len(res); n > 0 && (r.Start <= res[n-1].End || r.Start-1 == res[n-1].End) {
+			if r.End > res[n-1].End {
+				res[n-1].End = r.End
+			}
+			continue
+		}
+		res = append(res, r)
+	}
+	return IntSet{ranges: res}
+}
+
+// Ranges returns the merged ranges in ascending order.
+func (s IntSet) Ranges() []IntRange {
+	return append([]IntRange{}, s.ranges...)
+}
+
+func (s IntSet) IsEmpty() bool {
+	return len(s.ranges) == 0
+}
+
+// Len returns the number of integers in the set, capped at math.MaxInt64.
+func (s IntSet) Len() int64 {
+	res := int64(0)
+	for _, r := range s.ranges {
+		if n := r.Len(); n > math.MaxInt64-res {
+			return math.MaxInt64
+		} else {
+			res += n
+		}
+	}
+	return res
+}
+
+func (s IntSet) Contains(n int64) bool {
+	i := sort.Search(len(s.ranges), func(i int) bool {
+		return s.ranges[i].End >= n
+	})
+	return i < len(s.ranges) && s.ranges[i].Start <= n
+}
+
+// ForEach calls fn for every integer in the set in ascending order.
+func (s IntSet) ForEach(fn func(int64)) {
+	for _, r := range s.ranges {
+		for n := r.Start; ; n++ {
+			fn(n)
+			if n == r.End {
+				break
+			}
+		}
+	}
+}
+
+// Values returns all the integers in the set in ascending order.
+func (s IntSet) Values() []int64 {
+	res := []int64{}
+	s.ForEach(func(n int64) {
+		res = append(res, n)
+	})
+	return res
+}
+
+// String formats the set the way ParseIntSet accepts it, e.g. "1-5,8".
+func (s IntSet) String() string {
+	parts := make([]string, len(s.ranges))
+	for i, r := range s.ranges {
+		parts[i] = strconv.FormatInt(r.Start, 10)
+		if r.End != r.Start {
+			parts[i] += "-" + strconv.FormatInt(r.End, 10)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func (s *StringValue) ParseIntSet() (IntSet, error) {
+	if s == nil {
+		return IntSet{}, UnspecifiedValueErr
+	}
+	return ParseIntSet(string(*s))
+}
+
+func (s *StringValue) IntSet(def ...IntSet) IntSet {
+	defVal := IntSet{}
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseIntSet(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
@@ -0,0 +1,94 @@
+package simplequery
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseIntSet(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res IntSet
+	var err error
+
+	res, err = ParseIntSet("1-5,8,10-12")
+	Ω(err).Should(BeNil())
+	Ω(res.Ranges()).Should(Equal([]IntRange{{1, 5}, {8, 8}, {10, 12}}))
+	Ω(res.Len()).Should(Equal(int64(9)))
+	Ω(res.String()).Should(Equal("1-5,8,10-12"))
+	Ω(res.Values()).Should(Equal([]int64{1, 2, 3, 4, 5, 8, 10, 11, 12}))
+
+	res, err = ParseIntSet(" 10-12, 3 ,1-4,5,11, 7 - 7 ")
+	Ω(err).Should(BeNil())
+	Ω(res.Ranges()).Should(Equal([]IntRange{{1, 5}, {7, 7}, {10, 12}}))
+	Ω(res.String()).Should(Equal("1-5,7,10-12"))
+
+	for _, n := range []int64{1, 3, 5, 7, 10, 12} {
+		Ω(res.Contains(n)).Should(BeTrue(), "%d", n)
+	}
+	for _, n := range []int64{-1, 0, 6, 8, 9, 13} {
+		Ω(res.Contains(n)).Should(BeFalse(), "%d", n)
+	}
+
+	// Overlapping ranges count once.
+	res, err = ParseIntSet("1-10000,1-10000")
+	Ω(err).Should(BeNil())
+	Ω(res.Len()).Should(Equal(int64(10000)))
+
+	_, err = ParseIntSet("1-999999999")
+	Ω(err).Should(Equal(TooManyItemsErr))
+
+	_, err = ParseIntSet("0-9223372036854775807")
+	Ω(err).Should(Equal(TooManyItemsErr))
+
+	_, err = ParseIntSet("0-10000,20000")
+	Ω(err).Should(Equal(TooManyItemsErr))
+
+	for _, str := range []string{"", "1,", "a", "5-1", "-3", "1-", "1-2-3", "+1", "1--2"} {
+		_, err = ParseIntSet(str)
+		Ω(err).ShouldNot(BeNil(), str)
+		Ω(err.(*ParseError).Kind).Should(Equal("integer set"))
+	}
+}
+
+func TestIntSet(t *testing.T) {
+	RegisterTestingT(t)
+
+	var s IntSet
+
+	Ω(s.IsEmpty()).Should(BeTrue())
+	Ω(s.Len()).Should(Equal(int64(0)))
+	Ω(s.Contains(0)).Should(BeFalse())
+	Ω(s.Values()).Should(Equal([]int64{}))
+	Ω(s.String()).Should(Equal(""))
+
+	s = NewIntSet(IntRange{5, 6}, IntRange{3, 1}, IntRange{1, 4})
+	Ω(s.Ranges()).Should(Equal([]IntRange{{1, 6}}))
+
+	// Ranges returns a copy.
+	s.Ranges()[0].End = 100
+	Ω(s.Len()).Should(Equal(int64(6)))
+
+	s = NewIntSet(IntRange{math.MaxInt64 - 1, math.MaxInt64}, IntRange{math.MaxInt64, math.MaxInt64})
+	Ω(s.Ranges()).Should(Equal([]IntRange{{math.MaxInt64 - 1, math.MaxInt64}}))
+	Ω(s.Values()).Should(Equal([]int64{math.MaxInt64 - 1, math.MaxInt64}))
+
+	s = NewIntSet(IntRange{math.MinInt64, -1}, IntRange{0, math.MaxInt64})
+	Ω(s.Ranges()).Should(Equal([]IntRange{{math.MinInt64, math.MaxInt64}}))
+	Ω(s.Len()).Should(Equal(int64(math.MaxInt64)))
+}
+
+func TestStringValueIntSet(t *testing.T) {
+	RegisterTestingT(t)
+
+	var err error
+
+	Ω(psv("1-3,5").IntSet().String()).Should(Equal("1-3,5"))
+	Ω(psv("x").IntSet().IsEmpty()).Should(BeTrue())
+	Ω(psv("x").IntSet(NewIntSet(IntRange{1, 1})).String()).Should(Equal("1"))
+
+	_, err = (*StringValue)(nil).ParseIntSet()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+}
@@ -0,0 +1,105 @@
+package simplequery
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+var (
+	JSONTooDeepErr = errors.New("The parameter value is nested too deeply")
+)
+
+// JSONOptions control how StringValue.JSON decodes embedded JSON values.
+type JSONOptions struct {
+	// MaxSize is the maximum length of the value in bytes. 0 means the
+	// DefaultJSONOptions limit and a negative value means no limit.
+	MaxSize int
+	// MaxDepth is the maximum nesting depth of arrays and objects. 0 means the
+	// DefaultJSONOptions limit and a negative value means no limit.
+	MaxDepth int
+	// DisallowUnknownFields rejects objects with keys that do not match any
+	// exported field of the destination struct.
+	DisallowUnknownFields bool
+	// UseNumber decodes numbers into interface{} values as json.Number
+	// rather than float64.
+	UseNumber bool
+}
+
+// DefaultJSONOptions are used by StringValue.JSON when no options are given.
+// Their limits also apply when the given options leave MaxSize or MaxDepth
+// zero.
+var DefaultJSONOptions = JSONOptions{
+	MaxSize:  16 << 10,
+	MaxDepth: 32,
+}
+
+// JSON decodes a JSON-encoded value, such as `{"status":"open"}` or
+// `[1,2,3]`, into dst. The value must consist of a single JSON document.
+func (s *StringValue) JSON(dst interface{}, opts ...JSONOptions) error {
+	if s == nil {
+		return UnspecifiedValueErr
+	}
+	o := DefaultJSONOptions
+	if len(opts) > 0 {
+		o = opts[0]
+		if o.MaxSize == 0 {
+			o.MaxSize = DefaultJSONOptions.MaxSize
+		}
+		if o.MaxDepth == 0 {
+			o.MaxDepth = DefaultJSONOptions.MaxDepth
+		}
+	}
+	return decodeJSON([]byte(*s), dst, o)
+}
+
+func decodeJSON(data []byte, dst interface{}, o JSONOptions) error {
+	if o.MaxSize > 0 && len(data) > o.MaxSize {
+		return ValueTooLargeErr
+	}
+	if o.MaxDepth > 0 && jsonDepth(data) > o.MaxDepth {
+		return JSONTooDeepErr
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if o.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if o.UseNumber {
+		dec.UseNumber()
+	}
+	if err := dec.Decode(dst); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("json: unexpected data after top-level value")
+	}
+	return nil
+}
+
+// jsonDepth returns the maximum nesting depth of arrays and objects in data.
+// It does not validate data, which is left to the decoder.
+func jsonDepth(data []byte) int {
+	depth, max := 0, 0
+	inString, escaped := false, false
+	for _, c := range data {
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '[' || c == '{':
+			depth++
+			if depth > max {
+				max = depth
+			}
+		case c == ']' || c == '}':
+			depth--
+		}
+	}
+	return max
+}
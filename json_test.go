@@ -0,0 +1,95 @@
+package simplequery
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type testFilter struct {
+	Status string `json:"status"`
+	Limit  int    `json:"limit"`
+}
+
+func TestStringValueJSON(t *testing.T) {
+	RegisterTestingT(t)
+
+	var err error
+
+	var filter testFilter
+	err = psv(`{"status":"open","limit":5}`).JSON(&filter)
+	Ω(err).Should(BeNil())
+	Ω(filter).Should(Equal(testFilter{Status: "open", Limit: 5}))
+
+	var ids []int
+	err = psv(`[1,2,3]`).JSON(&ids)
+	Ω(err).Should(BeNil())
+	Ω(ids).Should(Equal([]int{1, 2, 3}))
+
+	var raw interface{}
+	err = psv(`{"n":1}`).JSON(&raw, JSONOptions{UseNumber: true})
+	Ω(err).Should(BeNil())
+	Ω(raw).Should(Equal(map[string]interface{}{"n": json.Number("1")}))
+
+	err = psv(`{"status":"open","extra":1}`).JSON(&filter)
+	Ω(err).Should(BeNil())
+
+	err = psv(`{"status":"open","extra":1}`).JSON(&filter, JSONOptions{DisallowUnknownFields: true})
+	Ω(err).ShouldNot(BeNil())
+	Ω(err.Error()).Should(ContainSubstring("unknown field"))
+
+	err = psv(`[1,2,3] [4]`).JSON(&ids)
+	Ω(err).ShouldNot(BeNil())
+
+	err = psv(`[1,2,`).JSON(&ids)
+	Ω(err).ShouldNot(BeNil())
+
+	err = psv(`{"status":1}`).JSON(&filter)
+	Ω(err).ShouldNot(BeNil())
+
+	err = (*StringValue)(nil).JSON(&filter)
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+}
+
+func TestStringValueJSON_Limits(t *testing.T) {
+	RegisterTestingT(t)
+
+	var err error
+	var raw interface{}
+
+	deep := strings.Repeat("[", 33) + strings.Repeat("]", 33)
+	err = psv(deep).JSON(&raw)
+	Ω(err).Should(Equal(JSONTooDeepErr))
+
+	err = psv(deep).JSON(&raw, JSONOptions{MaxDepth: 33})
+	Ω(err).Should(BeNil())
+
+	// Zero limits fall back to the defaults, negative ones disable them.
+	err = psv(deep).JSON(&raw, JSONOptions{})
+	Ω(err).Should(Equal(JSONTooDeepErr))
+
+	err = psv(deep).JSON(&raw, JSONOptions{DisallowUnknownFields: true})
+	Ω(err).Should(Equal(JSONTooDeepErr))
+
+	err = psv(deep).JSON(&raw, JSONOptions{MaxDepth: -1})
+	Ω(err).Should(BeNil())
+
+	// Brackets in strings do not count.
+	err = psv(`["`+strings.Repeat("[", 40)+`\"{"]`).JSON(&raw, JSONOptions{MaxDepth: 1})
+	Ω(err).Should(BeNil())
+
+	long := `"` + strings.Repeat("a", 16<<10) + `"`
+	err = psv(long).JSON(&raw)
+	Ω(err).Should(Equal(ValueTooLargeErr))
+
+	err = psv(long).JSON(&raw, JSONOptions{MaxSize: 32 << 10})
+	Ω(err).Should(BeNil())
+
+	err = psv(long).JSON(&raw, JSONOptions{UseNumber: true})
+	Ω(err).Should(Equal(ValueTooLargeErr))
+
+	err = psv(long).JSON(&raw, JSONOptions{MaxSize: -1})
+	Ω(err).Should(BeNil())
+}
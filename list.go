@@ -0,0 +1,198 @@
+package simplequery
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	TooManyItemsErr = errors.New("The parameter value has too many items")
+)
+
+// ListOptions control how list values, e.g. "a, b,,\"c,d\"", are split.
+//
+// Zero Sep and MaxItems take the defaults, but the flags are used as given:
+// to change only some of them, start from DefaultListOptions, e.g.
+//
+//	o := DefaultListOptions
+//	o.Unique = true
+type ListOptions struct {
+	// Sep separates the items, "," if empty.
+	Sep string
+	// Trim removes the leading and trailing whitespace of unquoted items.
+	Trim bool
+	// DropEmpty skips empty items.
+	DropEmpty bool
+	// Quoted allows items enclosed in double quotes, which may contain the
+	// separator. A double quote inside a quoted item is written as "".
+	Quoted bool
+	// Unique keeps only the first occurrence of every item.
+	Unique bool
+	// MaxItems is the maximum number of resulting items. 0 means the
+	// DefaultListOptions limit and a negative value means no limit.
+	MaxItems int
+}
+
+// DefaultListOptions are used by ParseList when no options are given.
+var DefaultListOptions = ListOptions{
+	Sep:       ",",
+	Trim:      true,
+	DropEmpty: true,
+	Quoted:    true,
+	MaxItems:  1000,
+}
+
+// ParseList splits str into items according to opts.
+func ParseList(str string, opts ListOptions) ([]string, error) {
+	l := newListBuilder(opts)
+	if err := l.add(str); err != nil {
+		return nil, err
+	}
+	return l.items, nil
+}
+
+type listBuilder struct {
+	opts  ListOptions
+	items []string
+	seen  map[string]bool
+}
+
+func newListBuilder(opts ListOptions) *listBuilder {
+	if opts.Sep == "" {
+		opts.Sep = ","
+	}
+	if opts.MaxItems == 0 {
+		opts.MaxItems = DefaultListOptions.MaxItems
+	}
+	l := &listBuilder{opts: opts, items: []string{}}
+	if opts.Unique {
+		l.seen = map[string]bool{}
+	}
+	return l
+}
+
+func (l *listBuilder) add(str string) error {
+	for pos := 0; ; {
+		item, next, more, err := l.next(str, pos)
+		if err != nil {
+			return &ParseError{"list", str, err}
+		}
+		if err := l.append(item); err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+		pos = next
+	}
+}
+
+// next reads the item starting at pos. It returns the position following the
+// separator after the item, and whether there is such separator.
+func (l *listBuilder) next(str string, pos int) (string, int, bool, error) {
+	sep := l.opts.Sep
+
+	if l.opts.Quoted {
+		start := pos
+		if l.opts.Trim {
+			start = skipSpaces(str, start)
+		}
+		if start < len(str) && str[start] == '"' {
+			return l.nextQuoted(str, start+1)
+		}
+	}
+
+	end := strings.Index(str[pos:], sep)
+	if end < 0 {
+		item := str[pos:]
+		if l.opts.Trim {
+			item = strings.TrimSpace(item)
+		}
+		return item, len(str), false, nil
+	}
+	item := str[pos : pos+end]
+	if l.opts.Trim {
+		item = strings.TrimSpace(item)
+	}
+	return item, pos + end + len(sep), true, nil
+}
+
+func (l *listBuilder) nextQuoted(str string, pos int) (string, int, bool, error) {
+	var b strings.Builder
+	for {
+		idx := strings.IndexByte(str[pos:], '"')
+		if idx < 0 {
+			return "", 0, false, errors.New("unterminated quoted item")
+		}
+		b.WriteString(str[pos : pos+idx])
+		pos += idx + 1
+		if pos < len(str) && str[pos] == '"' {
+			b.WriteByte('"')
+			pos++
+			continue
+		}
+		break
+	}
+
+	if l.opts.Trim {
+		pos = skipSpaces(str, pos)
+	}
+	switch {
+	case pos == len(str):
+		return b.String(), pos, false, nil
+	case strings.HasPrefix(str[pos:], l.opts.Sep):
+		return b.String(), pos + len(l.opts.Sep), true, nil
+	}
+	return "", 0, false, errors.New("unexpected text after quoted item")
+}
+
+func (l *listBuilder) append(item string) error {
+	if l.opts.DropEmpty && item == "" {
+		return nil
+	}
+	if l.seen != nil {
+		if l.seen[item] {
+			return nil
+		}
+		l.seen[item] = true
+	}
+	if l.opts.MaxItems > 0 && len(l.items) >= l.opts.MaxItems {
+		return TooManyItemsErr
+	}
+	l.items = append(l.items, item)
+	return nil
+}
+
+func skipSpaces(str string, pos int) int {
+	for pos < len(str) && (str[pos] == ' ' || str[pos] == '\t') {
+		pos++
+	}
+	return pos
+}
+
+// ParseList splits the value into items, see ParseList. Unlike List, it
+// handles quoting, whitespace and empty items according to the options.
+func (s *StringValue) ParseList(opts ...ListOptions) (ValueSet, error) {
+	if s == nil {
+		return ValueSet{}, UnspecifiedValueErr
+	}
+	return ValueSet{*s}.ParseList(opts...)
+}
+
+// ParseList splits every value into items and merges them into one set, e.g.
+// "?tags=a,b&tags=c" results in "a", "b" and "c". Unique and MaxItems apply
+// to the merged set.
+func (s ValueSet) ParseList(opts ...ListOptions) (ValueSet, error) {
+	o := DefaultListOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	l := newListBuilder(o)
+	for i := range s {
+		if err := l.add(string(s[i])); err != nil {
+			return ValueSet{}, err
+		}
+	}
+	return ValueSetFrom(l.items), nil
+}
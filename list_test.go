@@ -0,0 +1,108 @@
+package simplequery
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseList(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res []string
+	var err error
+
+	res, err = ParseList(`a, b,,"c,d"`, ListOptions{})
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal([]string{"a", " b", "", `"c`, `d"`}))
+
+	res, err = ParseList(`a, b,,"c,d"`, DefaultListOptions)
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal([]string{"a", "b", "c,d"}))
+
+	res, err = ParseList(` "say ""hi""" , x `, DefaultListOptions)
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal([]string{`say "hi"`, "x"}))
+
+	res, err = ParseList(`a,"",b,`, ListOptions{Quoted: true})
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal([]string{"a", "", "b", ""}))
+
+	res, err = ParseList("", ListOptions{})
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal([]string{""}))
+
+	res, err = ParseList("", DefaultListOptions)
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal([]string{}))
+
+	res, err = ParseList("a|b||c", ListOptions{Sep: "||"})
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal([]string{"a|b", "c"}))
+
+	res, err = ParseList("b,a,b,c,a", ListOptions{Unique: true})
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal([]string{"b", "a", "c"}))
+
+	res, err = ParseList("a,b,a", ListOptions{Unique: true, MaxItems: 2})
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal([]string{"a", "b"}))
+
+	_, err = ParseList("a,b,c", ListOptions{MaxItems: 2})
+	Ω(err).Should(Equal(TooManyItemsErr))
+
+	_, err = ParseList(`a,"b`, DefaultListOptions)
+	Ω(err).Should(MatchError(`parsing list "a,\"b": unterminated quoted item`))
+
+	_, err = ParseList(`"a"b,c`, DefaultListOptions)
+	Ω(err).Should(MatchError(`parsing list "\"a\"b,c": unexpected text after quoted item`))
+}
+
+func TestStringValueParseList(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res ValueSet
+	var err error
+
+	res, err = psv(`a, b,,"c,d"`).ParseList()
+	Ω(err).Should(BeNil())
+	Ω(res.Strings()).Should(Equal([]string{"a", "b", "c,d"}))
+
+	res, err = psv("a;b").ParseList(ListOptions{Sep: ";"})
+	Ω(err).Should(BeNil())
+	Ω(res.Strings()).Should(Equal([]string{"a", "b"}))
+
+	_, err = (*StringValue)(nil).ParseList()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+
+	q := FromQuery(map[string][]string{
+		"tags": {"a,b", " b , c", ""},
+	})
+	res, err = q.GetAll("tags").ParseList(ListOptions{Trim: true, DropEmpty: true, Unique: true})
+	Ω(err).Should(BeNil())
+	Ω(res.Strings()).Should(Equal([]string{"a", "b", "c"}))
+
+	_, err = q.GetAll("tags").ParseList(ListOptions{MaxItems: 3})
+	Ω(err).Should(Equal(TooManyItemsErr))
+
+	res, err = q.GetAll("missing").ParseList()
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(ValueSet{}))
+
+	// Partial options keep the default limit, a negative one removes it.
+	long := psv(strings.Repeat("a,", 1000) + "a")
+	_, err = long.ParseList(ListOptions{Unique: true})
+	Ω(err).Should(BeNil())
+	_, err = long.ParseList(ListOptions{Sep: ","})
+	Ω(err).Should(Equal(TooManyItemsErr))
+	res, err = long.ParseList(ListOptions{MaxItems: -1})
+	Ω(err).Should(BeNil())
+	Ω(res).Should(HaveLen(1001))
+
+	o := DefaultListOptions
+	o.Unique = true
+	res, err = q.GetAll("tags").ParseList(o)
+	Ω(err).Should(BeNil())
+	Ω(res.Strings()).Should(Equal([]string{"a", "b", "c"}))
+}
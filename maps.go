@@ -0,0 +1,87 @@
+package simplequery
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParseMap splits the value into key/value pairs, e.g. "env:prod,team:core"
+// with pairSep "," and kvSep ":". Whitespace around keys and values is
+// trimmed, empty pairs are skipped and a pair without kvSep has an empty
+// value. Repeated keys keep all their values in order.
+func (s *StringValue) ParseMap(pairSep, kvSep string) (map[string]ValueSet, error) {
+	if s == nil {
+		return map[string]ValueSet{}, UnspecifiedValueErr
+	}
+
+	str := string(*s)
+	res := map[string]ValueSet{}
+	for _, pair := range strings.Split(str, pairSep) {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, val, _ := strings.Cut(pair, kvSep)
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return map[string]ValueSet{}, &ParseError{"map", str, errors.New("empty key")}
+		}
+		res[key] = append(res[key], StringValue(strings.TrimSpace(val)))
+	}
+	return res, nil
+}
+
+// Map is like ParseMap but returns an empty map if the value is missing or
+// malformed.
+func (s *StringValue) Map(pairSep, kvSep string) map[string]ValueSet {
+	res, _ := s.ParseMap(pairSep, kvSep)
+	return res
+}
+
+// Map collects the parameters named prefix[key], e.g. "meta[env]=prod", into
+// a map by key. Parameters with an empty or nested key are skipped; collect
+// list parameters such as "tags[]=a" with Q.List instead.
+func (q Q) Map(prefix string) map[string]ValueSet {
+	res := map[string]ValueSet{}
+	for k, vs := range q {
+		if key, ok := mapKey(k, prefix); ok {
+			res[key] = append(res[key], vs...)
+		}
+	}
+	return res
+}
+
+// mapKey returns the key of a prefix[key] parameter.
+func mapKey(param, prefix string) (string, bool) {
+	if !strings.HasPrefix(param, prefix+"[") || !strings.HasSuffix(param, "]") {
+		return "", false
+	}
+	key := param[len(prefix)+1 : len(param)-1]
+	if key == "" || strings.ContainsAny(key, "[]") {
+		return "", false
+	}
+	return key, true
+}
+
+// ConvertMap converts the values of m to T. The value of every key is picked
+// by policy, FirstValue if nil; e.g. SingleValue rejects repeated keys.
+func ConvertMap[T any](m map[string]ValueSet, policy Policy) (map[string]T, error) {
+	if policy == nil {
+		policy = FirstValue
+	}
+
+	res := make(map[string]T, len(m))
+	for key, vs := range m {
+		val, err := policy(vs)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		if val == nil {
+			continue
+		}
+		if res[key], err = Parse[T](val); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return res, nil
+}
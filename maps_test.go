@@ -0,0 +1,87 @@
+package simplequery
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestStringValueMap(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res map[string]ValueSet
+	var err error
+
+	res, err = psv("env:prod, team : core,,flag,env:dev").ParseMap(",", ":")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(map[string]ValueSet{
+		"env":  ValueSetFrom([]string{"prod", "dev"}),
+		"team": ValueSetFrom([]string{"core"}),
+		"flag": ValueSetFrom([]string{""}),
+	}))
+
+	res, err = psv("a=1;b=x=y").ParseMap(";", "=")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(map[string]ValueSet{
+		"a": ValueSetFrom([]string{"1"}),
+		"b": ValueSetFrom([]string{"x=y"}),
+	}))
+
+	res, err = psv("").ParseMap(",", ":")
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(map[string]ValueSet{}))
+
+	_, err = psv("a:1,:2").ParseMap(",", ":")
+	Ω(err).Should(MatchError(`parsing map "a:1,:2": empty key`))
+	Ω(psv("a:1,:2").Map(",", ":")).Should(Equal(map[string]ValueSet{}))
+
+	_, err = (*StringValue)(nil).ParseMap(",", ":")
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	Ω((*StringValue)(nil).Map(",", ":")).Should(Equal(map[string]ValueSet{}))
+}
+
+func TestQMap(t *testing.T) {
+	RegisterTestingT(t)
+
+	q := FromQuery(map[string][]string{
+		"meta[env]":    {"prod"},
+		"meta[team]":   {"core", "infra"},
+		"meta[]":       {"x"},
+		"meta[a][b]":   {"x"},
+		"meta":         {"x"},
+		"metadata[id]": {"x"},
+		"labels[env]":  {"dev"},
+	})
+
+	Ω(q.Map("meta")).Should(Equal(map[string]ValueSet{
+		"env":  ValueSetFrom([]string{"prod"}),
+		"team": ValueSetFrom([]string{"core", "infra"}),
+	}))
+	Ω(q.Map("missing")).Should(Equal(map[string]ValueSet{}))
+}
+
+func TestConvertMap(t *testing.T) {
+	RegisterTestingT(t)
+
+	m := map[string]ValueSet{
+		"a": ValueSetFrom([]string{"1", "2"}),
+		"b": ValueSetFrom([]string{"3"}),
+		"c": {},
+	}
+
+	Ω(ConvertMap[int](m, nil)).Should(Equal(map[string]int{"a": 1, "b": 3}))
+	Ω(ConvertMap[int](m, LastValue)).Should(Equal(map[string]int{"a": 2, "b": 3}))
+	Ω(ConvertMap[string](m, JoinValues("+"))).Should(Equal(map[string]string{"a": "1+2", "b": "3"}))
+
+	_, err := ConvertMap[int](m, SingleValue)
+	Ω(errors.Is(err, DuplicateValueErr)).Should(BeTrue())
+	Ω(err.Error()).Should(HavePrefix("a: "))
+
+	_, err = ConvertMap[bool](m, LastValue)
+	Ω(err).ShouldNot(BeNil())
+
+	res, err := ConvertMap[int](psv("x:1,y:2").Map(",", ":"), SingleValue)
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(map[string]int{"x": 1, "y": 2}))
+}
@@ -0,0 +1,140 @@
+package simplequery
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+)
+
+var (
+	FrozenQueryErr = errors.New("The query is frozen and cannot be modified")
+)
+
+// MemoQ is a query that is safe for concurrent use and caches the typed
+// values returned by Cached, so that middleware, handlers and loggers reading
+// the same keys parse them only once.
+//
+// Cached values are shared by all the readers; values of pointer types, e.g.
+// *big.Int, must not be modified.
+type MemoQ struct {
+	mu     sync.RWMutex
+	q      Q
+	frozen bool
+	cache  map[memoKey]memoEntry
+}
+
+type memoKey struct {
+	key string
+	typ reflect.Type
+}
+
+type memoEntry struct {
+	val interface{}
+	err error
+}
+
+// NewMemoQ returns a MemoQ with a copy of q.
+func NewMemoQ(q Q) *MemoQ {
+	return &MemoQ{q: q.Clone(), cache: map[memoKey]memoEntry{}}
+}
+
+func (m *MemoQ) Has(key string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.q.Has(key)
+}
+
+// Get returns a copy of the first value of key.
+func (m *MemoQ) Get(key string) *StringValue {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.q.GetAll(key).First()
+}
+
+// GetAll returns a copy of the values of key.
+func (m *MemoQ) GetAll(key string) ValueSet {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append(ValueSet{}, m.q.GetAll(key)...)
+}
+
+// Q returns a copy of the query.
+func (m *MemoQ) Q() Q {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.q.Clone()
+}
+
+func (m *MemoQ) Set(key, value string) error {
+	return m.update(key, func() { m.q.Set(key, value) })
+}
+
+func (m *MemoQ) Add(key, value string) error {
+	return m.update(key, func() { m.q.Add(key, value) })
+}
+
+func (m *MemoQ) Del(key string) error {
+	return m.update(key, func() { m.q.Del(key) })
+}
+
+func (m *MemoQ) update(key string, fn func()) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.frozen {
+		return FrozenQueryErr
+	}
+	fn()
+	for k := range m.cache {
+		if k.key == key {
+			delete(m.cache, k)
+		}
+	}
+	return nil
+}
+
+// Freeze returns an immutable snapshot of the query, which rejects any
+// modification with FrozenQueryErr. The snapshot starts with the values
+// cached so far.
+func (m *MemoQ) Freeze() *MemoQ {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	res := &MemoQ{
+		q:      m.q.Clone(),
+		frozen: true,
+		cache:  make(map[memoKey]memoEntry, len(m.cache)),
+	}
+	for k, e := range m.cache {
+		res.cache[k] = e
+	}
+	return res
+}
+
+func (m *MemoQ) Frozen() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.frozen
+}
+
+// Cached returns the first value of key converted to T with Parse. The result,
+// including the error, is cached per key and type until the key is modified.
+func Cached[T any](m *MemoQ, key string) (T, error) {
+	k := memoKey{key, typeOf[T]()}
+
+	m.mu.RLock()
+	e, ok := m.cache[k]
+	m.mu.RUnlock()
+
+	if !ok {
+		m.mu.Lock()
+		if e, ok = m.cache[k]; !ok {
+			val, err := Parse[T](m.q.Get(key))
+			e = memoEntry{val, err}
+			m.cache[k] = e
+		}
+		m.mu.Unlock()
+	}
+
+	res, _ := e.val.(T)
+	return res, e.err
+}
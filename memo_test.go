@@ -0,0 +1,97 @@
+package simplequery
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestMemoQ(t *testing.T) {
+	RegisterTestingT(t)
+
+	var err error
+
+	q := FromQuery(map[string][]string{
+		"limit": {"10", "20"},
+		"name":  {"x"},
+	})
+	m := NewMemoQ(q)
+
+	// The original query is copied.
+	q.Set("limit", "30")
+	Ω(m.Get("limit").String()).Should(Equal("10"))
+
+	Ω(m.Has("name")).Should(BeTrue())
+	Ω(m.Has("missing")).Should(BeFalse())
+	Ω(m.Get("missing")).Should(BeNil())
+	Ω(m.GetAll("limit").Strings()).Should(Equal([]string{"10", "20"}))
+
+	// Returned values are copies.
+	*m.Get("name") = "changed"
+	m.GetAll("limit")[0] = "changed"
+	m.Q().Set("name", "changed")
+	Ω(m.Get("name").String()).Should(Equal("x"))
+	Ω(m.Get("limit").String()).Should(Equal("10"))
+
+	Ω(Cached[int](m, "limit")).Should(Equal(10))
+	Ω(Cached[string](m, "limit")).Should(Equal("10"))
+	_, err = Cached[int](m, "name")
+	Ω(err).ShouldNot(BeNil())
+	_, err = Cached[int](m, "missing")
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+
+	Ω(m.Set("limit", "15")).Should(Succeed())
+	Ω(Cached[int](m, "limit")).Should(Equal(15))
+	Ω(m.Add("name", "y")).Should(Succeed())
+	Ω(m.GetAll("name").Strings()).Should(Equal([]string{"x", "y"}))
+	Ω(m.Del("name")).Should(Succeed())
+	Ω(m.Has("name")).Should(BeFalse())
+	Ω(m.Frozen()).Should(BeFalse())
+}
+
+func TestMemoQ_Freeze(t *testing.T) {
+	RegisterTestingT(t)
+
+	m := NewMemoQ(FromQuery(map[string][]string{"limit": {"10"}}))
+	Ω(Cached[int](m, "limit")).Should(Equal(10))
+
+	f := m.Freeze()
+	Ω(f.Frozen()).Should(BeTrue())
+	Ω(f.Set("limit", "1")).Should(Equal(FrozenQueryErr))
+	Ω(f.Add("limit", "1")).Should(Equal(FrozenQueryErr))
+	Ω(f.Del("limit")).Should(Equal(FrozenQueryErr))
+	Ω(Cached[int](f, "limit")).Should(Equal(10))
+
+	// The snapshot does not see later changes.
+	Ω(m.Set("limit", "20")).Should(Succeed())
+	Ω(Cached[int](m, "limit")).Should(Equal(20))
+	Ω(Cached[int](f, "limit")).Should(Equal(10))
+	Ω(f.Get("limit").String()).Should(Equal("10"))
+}
+
+func TestMemoQ_Concurrent(t *testing.T) {
+	RegisterTestingT(t)
+
+	m := NewMemoQ(FromQuery(map[string][]string{"limit": {"10"}, "at": {"123"}}))
+
+	var wg sync.WaitGroup
+	results := make([]int, 50)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = Cached[int](m, "limit")
+			_, _ = Cached[int64](m, "at")
+			_ = m.Get("at")
+			if i%10 == 0 {
+				_ = m.Set("other", "x")
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, res := range results {
+		Ω(res).Should(Equal(10))
+	}
+}
@@ -0,0 +1,543 @@
+package simplequery
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	OutOfRangeErr = errors.New("The parameter value is out of the allowed range")
+)
+
+// IntSyntax is a set of flags controlling which integer notations are
+// accepted by the integer accessors in addition to plain decimal numbers.
+type IntSyntax uint
+
+const (
+	// DecimalInts accepts base 10 integers only, so "010" is ten and "0x10"
+	// is an error.
+	DecimalInts IntSyntax = 0
+	// BasePrefixInts accepts the "0x", "0o" and "0b" prefixes, and treats
+	// integers with a leading zero as octal, as Go integer literals do.
+	BasePrefixInts IntSyntax = 1 << iota
+	// UnderscoreInts accepts underscores between digits, e.g. "1_000".
+	UnderscoreInts
+)
+
+// DefaultIntSyntax is the integer notation used by all integer accessors of
+// StringValue and ValueSet, and so by the built-in parsers of a Registry and
+// by Decode. It should be changed only during initialization,
+// e.g. set it to BasePrefixInts|UnderscoreInts to accept any Go integer
+// literal.
+var DefaultIntSyntax = DecimalInts
+
+func (s *StringValue) parseInt(bitSize int) (int64, error) {
+	if s == nil {
+		return 0, UnspecifiedValueErr
+	}
+	str, base := DefaultIntSyntax.prepare(string(*s))
+	return strconv.ParseInt(str, base, bitSize)
+}
+
+func (s *StringValue) parseUint(bitSize int) (uint64, error) {
+	if s == nil {
+		return 0, UnspecifiedValueErr
+	}
+	str, base := DefaultIntSyntax.prepare(string(*s))
+	return strconv.ParseUint(str, base, bitSize)
+}
+
+// prepare returns the string and the base to pass to strconv.
+func (syn IntSyntax) prepare(str string) (string, int) {
+	switch {
+	case syn&BasePrefixInts != 0:
+		if syn&UnderscoreInts == 0 && strings.Contains(str, "_") {
+			// Base 0 accepts underscores, make sure strconv rejects them.
+			return str, 10
+		}
+		return str, 0
+	case syn&UnderscoreInts != 0 && validUnderscores(str):
+		return strings.Replace(str, "_", "", -1), 10
+	}
+	return str, 10
+}
+
+// validUnderscores reports whether every underscore in str separates two
+// digits.
+func validUnderscores(str string) bool {
+	for i := range str {
+		if str[i] != '_' {
+			continue
+		}
+		if i == 0 || i == len(str)-1 || !isDigit(str[i-1]) || !isDigit(str[i+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func (s *StringValue) ParseInt() (int, error) {
+	val, err := s.parseInt(strconv.IntSize)
+	return int(val), err
+}
+
+func (s *StringValue) Int(def ...int) int {
+	defVal := int(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseInt(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseInt8() (int8, error) {
+	val, err := s.parseInt(8)
+	return int8(val), err
+}
+
+func (s *StringValue) Int8(def ...int8) int8 {
+	defVal := int8(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseInt8(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseInt16() (int16, error) {
+	val, err := s.parseInt(16)
+	return int16(val), err
+}
+
+func (s *StringValue) Int16(def ...int16) int16 {
+	defVal := int16(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseInt16(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseInt32() (int32, error) {
+	val, err := s.parseInt(32)
+	return int32(val), err
+}
+
+func (s *StringValue) Int32(def ...int32) int32 {
+	defVal := int32(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseInt32(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseUint() (uint, error) {
+	val, err := s.parseUint(strconv.IntSize)
+	return uint(val), err
+}
+
+func (s *StringValue) Uint(def ...uint) uint {
+	defVal := uint(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseUint(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseUint8() (uint8, error) {
+	val, err := s.parseUint(8)
+	return uint8(val), err
+}
+
+func (s *StringValue) Uint8(def ...uint8) uint8 {
+	defVal := uint8(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseUint8(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseUint16() (uint16, error) {
+	val, err := s.parseUint(16)
+	return uint16(val), err
+}
+
+func (s *StringValue) Uint16(def ...uint16) uint16 {
+	defVal := uint16(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseUint16(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseUint32() (uint32, error) {
+	val, err := s.parseUint(32)
+	return uint32(val), err
+}
+
+func (s *StringValue) Uint32(def ...uint32) uint32 {
+	defVal := uint32(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseUint32(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseFloat32() (float32, error) {
+	if s == nil {
+		return 0, UnspecifiedValueErr
+	}
+	val, err := strconv.ParseFloat(string(*s), 32)
+	return float32(val), err
+}
+
+func (s *StringValue) Float32(def ...float32) float32 {
+	defVal := float32(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseFloat32(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+// ParseIntBetween parses the value as int and checks that it lies within
+// [min, max]. A value outside of the range yields OutOfRangeErr.
+func (s *StringValue) ParseIntBetween(min, max int) (int, error) {
+	val, err := s.ParseInt()
+	if err != nil {
+		return 0, err
+	}
+	if val < min || val > max {
+		return val, OutOfRangeErr
+	}
+	return val, nil
+}
+
+// IntBetween returns the default value if the value is missing, malformed
+// or lies outside of [min, max].
+func (s *StringValue) IntBetween(min, max int, def ...int) int {
+	defVal := int(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseIntBetween(min, max); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+// ClampInt parses the value as int and limits it to [min, max]. The default
+// value is returned only if the value is missing or malformed.
+func (s *StringValue) ClampInt(min, max int, def ...int) int {
+	defVal := int(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	val, err := s.ParseInt()
+	if err != nil && !isRangeErr(err) {
+		return defVal
+	}
+	switch {
+	case val < min:
+		return min
+	case val > max:
+		return max
+	}
+	return val
+}
+
+func (s *StringValue) ParseInt64Between(min, max int64) (int64, error) {
+	val, err := s.ParseInt64()
+	if err != nil {
+		return 0, err
+	}
+	if val < min || val > max {
+		return val, OutOfRangeErr
+	}
+	return val, nil
+}
+
+func (s *StringValue) Int64Between(min, max int64, def ...int64) int64 {
+	defVal := int64(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseInt64Between(min, max); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ClampInt64(min, max int64, def ...int64) int64 {
+	defVal := int64(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	val, err := s.ParseInt64()
+	if err != nil && !isRangeErr(err) {
+		return defVal
+	}
+	switch {
+	case val < min:
+		return min
+	case val > max:
+		return max
+	}
+	return val
+}
+
+func (s *StringValue) ParseUint64Between(min, max uint64) (uint64, error) {
+	val, err := s.ParseUint64()
+	if err != nil {
+		return 0, err
+	}
+	if val < min || val > max {
+		return val, OutOfRangeErr
+	}
+	return val, nil
+}
+
+func (s *StringValue) Uint64Between(min, max uint64, def ...uint64) uint64 {
+	defVal := uint64(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseUint64Between(min, max); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ClampUint64(min, max uint64, def ...uint64) uint64 {
+	defVal := uint64(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	val, err := s.ParseUint64()
+	if err != nil && !isRangeErr(err) {
+		return defVal
+	}
+	switch {
+	case val < min:
+		return min
+	case val > max:
+		return max
+	}
+	return val
+}
+
+func (s *StringValue) ParseFloat64Between(min, max float64) (float64, error) {
+	val, err := s.ParseFloat64()
+	if err != nil {
+		return 0, err
+	}
+	// Written this way to reject NaN as well.
+	if !(val >= min && val <= max) {
+		return val, OutOfRangeErr
+	}
+	return val, nil
+}
+
+func (s *StringValue) Float64Between(min, max float64, def ...float64) float64 {
+	defVal := float64(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseFloat64Between(min, max); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ClampFloat64(min, max float64, def ...float64) float64 {
+	defVal := float64(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	val, err := s.ParseFloat64()
+	if err != nil && !isRangeErr(err) || math.IsNaN(val) {
+		return defVal
+	}
+	switch {
+	case val < min:
+		return min
+	case val > max:
+		return max
+	}
+	return val
+}
+
+// isRangeErr reports whether err is a strconv range error. strconv returns
+// the saturated value along with such an error, which is what the Clamp*
+// accessors need.
+func isRangeErr(err error) bool {
+	var numErr *strconv.NumError
+	return errors.As(err, &numErr) && numErr.Err == strconv.ErrRange
+}
+
+func (s ValueSet) Ints() []int {
+	res := make([]int, len(s))
+	for i := range s {
+		res[i] = s[i].Int()
+	}
+	return res
+}
+
+func (s ValueSet) ParseInts(mode ...PluralMode) ([]int, error) {
+	return parseValues(s, (*StringValue).ParseInt, mode...)
+}
+
+func (s ValueSet) Int8s() []int8 {
+	res := make([]int8, len(s))
+	for i := range s {
+		res[i] = s[i].Int8()
+	}
+	return res
+}
+
+func (s ValueSet) ParseInt8s(mode ...PluralMode) ([]int8, error) {
+	return parseValues(s, (*StringValue).ParseInt8, mode...)
+}
+
+func (s ValueSet) Int16s() []int16 {
+	res := make([]int16, len(s))
+	for i := range s {
+		res[i] = s[i].Int16()
+	}
+	return res
+}
+
+func (s ValueSet) ParseInt16s(mode ...PluralMode) ([]int16, error) {
+	return parseValues(s, (*StringValue).ParseInt16, mode...)
+}
+
+func (s ValueSet) Int32s() []int32 {
+	res := make([]int32, len(s))
+	for i := range s {
+		res[i] = s[i].Int32()
+	}
+	return res
+}
+
+func (s ValueSet) ParseInt32s(mode ...PluralMode) ([]int32, error) {
+	return parseValues(s, (*StringValue).ParseInt32, mode...)
+}
+
+func (s ValueSet) Uints() []uint {
+	res := make([]uint, len(s))
+	for i := range s {
+		res[i] = s[i].Uint()
+	}
+	return res
+}
+
+func (s ValueSet) ParseUints(mode ...PluralMode) ([]uint, error) {
+	return parseValues(s, (*StringValue).ParseUint, mode...)
+}
+
+func (s ValueSet) Uint8s() []uint8 {
+	res := make([]uint8, len(s))
+	for i := range s {
+		res[i] = s[i].Uint8()
+	}
+	return res
+}
+
+func (s ValueSet) ParseUint8s(mode ...PluralMode) ([]uint8, error) {
+	return parseValues(s, (*StringValue).ParseUint8, mode...)
+}
+
+func (s ValueSet) Uint16s() []uint16 {
+	res := make([]uint16, len(s))
+	for i := range s {
+		res[i] = s[i].Uint16()
+	}
+	return res
+}
+
+func (s ValueSet) ParseUint16s(mode ...PluralMode) ([]uint16, error) {
+	return parseValues(s, (*StringValue).ParseUint16, mode...)
+}
+
+func (s ValueSet) Uint32s() []uint32 {
+	res := make([]uint32, len(s))
+	for i := range s {
+		res[i] = s[i].Uint32()
+	}
+	return res
+}
+
+func (s ValueSet) ParseUint32s(mode ...PluralMode) ([]uint32, error) {
+	return parseValues(s, (*StringValue).ParseUint32, mode...)
+}
+
+func (s ValueSet) Float32s() []float32 {
+	res := make([]float32, len(s))
+	for i := range s {
+		res[i] = s[i].Float32()
+	}
+	return res
+}
+
+func (s ValueSet) ParseFloat32s(mode ...PluralMode) ([]float32, error) {
+	return parseValues(s, (*StringValue).ParseFloat32, mode...)
+}
@@ -0,0 +1,249 @@
+package simplequery
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestStringValueInt(t *testing.T) {
+	RegisterTestingT(t)
+
+	var v *StringValue
+	var res int
+	var err error
+
+	v = psv("-12")
+
+	res, err = v.ParseInt()
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(-12))
+
+	res = v.Int(-99)
+	Ω(res).Should(Equal(-12))
+
+	v = nil
+
+	_, err = v.ParseInt()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+
+	res = v.Int()
+	Ω(res).Should(Equal(0))
+
+	res = v.Int(-99)
+	Ω(res).Should(Equal(-99))
+
+	v = psv("qwe")
+
+	_, err = v.ParseInt()
+	Ω(err).ShouldNot(BeNil())
+	Ω(err.Error()).Should(HavePrefix("strconv.ParseInt: parsing "))
+
+	res = v.Int(-99)
+	Ω(res).Should(Equal(-99))
+}
+
+func TestStringValueSizedInts(t *testing.T) {
+	RegisterTestingT(t)
+
+	var err error
+
+	Ω(psv("127").Int8()).Should(Equal(int8(127)))
+	Ω(psv("-128").Int8()).Should(Equal(int8(-128)))
+	Ω(psv("128").Int8(-1)).Should(Equal(int8(-1)))
+	_, err = psv("128").ParseInt8()
+	Ω(err).ShouldNot(BeNil())
+	Ω(err.(*strconv.NumError).Err).Should(Equal(strconv.ErrRange))
+
+	Ω(psv("32767").Int16()).Should(Equal(int16(32767)))
+	Ω(psv("32768").Int16(-1)).Should(Equal(int16(-1)))
+	_, err = psv("-32769").ParseInt16()
+	Ω(err.(*strconv.NumError).Err).Should(Equal(strconv.ErrRange))
+
+	Ω(psv("2147483647").Int32()).Should(Equal(int32(math.MaxInt32)))
+	Ω(psv("2147483648").Int32(-1)).Should(Equal(int32(-1)))
+	_, err = psv("2147483648").ParseInt32()
+	Ω(err.(*strconv.NumError).Err).Should(Equal(strconv.ErrRange))
+
+	var nilV *StringValue
+	_, err = nilV.ParseInt32()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	Ω(nilV.Int32(7)).Should(Equal(int32(7)))
+}
+
+func TestStringValueSizedUints(t *testing.T) {
+	RegisterTestingT(t)
+
+	var err error
+
+	Ω(psv("12").Uint()).Should(Equal(uint(12)))
+	Ω(psv("-1").Uint(9)).Should(Equal(uint(9)))
+
+	Ω(psv("255").Uint8()).Should(Equal(uint8(255)))
+	Ω(psv("256").Uint8(1)).Should(Equal(uint8(1)))
+	_, err = psv("256").ParseUint8()
+	Ω(err.(*strconv.NumError).Err).Should(Equal(strconv.ErrRange))
+
+	Ω(psv("65535").Uint16()).Should(Equal(uint16(65535)))
+	Ω(psv("65536").Uint16(1)).Should(Equal(uint16(1)))
+
+	Ω(psv("4294967295").Uint32()).Should(Equal(uint32(math.MaxUint32)))
+	Ω(psv("4294967296").Uint32(1)).Should(Equal(uint32(1)))
+	_, err = psv("4294967296").ParseUint32()
+	Ω(err.(*strconv.NumError).Err).Should(Equal(strconv.ErrRange))
+
+	var nilV *StringValue
+	_, err = nilV.ParseUint16()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	Ω(nilV.Uint16(7)).Should(Equal(uint16(7)))
+}
+
+func TestStringValueFloat32(t *testing.T) {
+	RegisterTestingT(t)
+
+	var err error
+
+	Ω(psv("1.5").Float32()).Should(Equal(float32(1.5)))
+	Ω(psv("x").Float32(-1)).Should(Equal(float32(-1)))
+
+	_, err = psv("1e39").ParseFloat32()
+	Ω(err.(*strconv.NumError).Err).Should(Equal(strconv.ErrRange))
+	Ω(psv("1e39").Float32(-1)).Should(Equal(float32(-1)))
+
+	var nilV *StringValue
+	_, err = nilV.ParseFloat32()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+}
+
+func TestStringValueIntBetween(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res int
+	var err error
+
+	res, err = psv("10").ParseIntBetween(1, 100)
+	Ω(err).Should(BeNil())
+	Ω(res).Should(Equal(10))
+
+	res, err = psv("1000").ParseIntBetween(1, 100)
+	Ω(err).Should(Equal(OutOfRangeErr))
+	Ω(res).Should(Equal(1000))
+
+	_, err = psv("0").ParseIntBetween(1, 100)
+	Ω(err).Should(Equal(OutOfRangeErr))
+
+	_, err = psv("a").ParseIntBetween(1, 100)
+	Ω(err).ShouldNot(BeNil())
+	Ω(err).ShouldNot(Equal(OutOfRangeErr))
+
+	Ω(psv("1").IntBetween(1, 100, 20)).Should(Equal(1))
+	Ω(psv("100").IntBetween(1, 100, 20)).Should(Equal(100))
+	Ω(psv("1000").IntBetween(1, 100, 20)).Should(Equal(20))
+	Ω(psv("a").IntBetween(1, 100, 20)).Should(Equal(20))
+	Ω((*StringValue)(nil).IntBetween(1, 100, 20)).Should(Equal(20))
+}
+
+func TestStringValueClampInt(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(psv("10").ClampInt(1, 100, 20)).Should(Equal(10))
+	Ω(psv("1000").ClampInt(1, 100, 20)).Should(Equal(100))
+	Ω(psv("-5").ClampInt(1, 100, 20)).Should(Equal(1))
+	Ω(psv("99999999999999999999999").ClampInt(1, 100, 20)).Should(Equal(100))
+	Ω(psv("-99999999999999999999999").ClampInt(1, 100, 20)).Should(Equal(1))
+	Ω(psv("a").ClampInt(1, 100, 20)).Should(Equal(20))
+	Ω((*StringValue)(nil).ClampInt(1, 100, 20)).Should(Equal(20))
+}
+
+func TestStringValueBetween_OtherTypes(t *testing.T) {
+	RegisterTestingT(t)
+
+	var err error
+
+	Ω(psv("10").Int64Between(1, 100)).Should(Equal(int64(10)))
+	Ω(psv("101").Int64Between(1, 100, 5)).Should(Equal(int64(5)))
+	_, err = psv("101").ParseInt64Between(1, 100)
+	Ω(err).Should(Equal(OutOfRangeErr))
+	Ω(psv("101").ClampInt64(1, 100)).Should(Equal(int64(100)))
+	Ω(psv("99999999999999999999999").ClampInt64(1, 100)).Should(Equal(int64(100)))
+
+	Ω(psv("10").Uint64Between(1, 100)).Should(Equal(uint64(10)))
+	Ω(psv("0").Uint64Between(1, 100, 5)).Should(Equal(uint64(5)))
+	_, err = psv("0").ParseUint64Between(1, 100)
+	Ω(err).Should(Equal(OutOfRangeErr))
+	Ω(psv("0").ClampUint64(1, 100)).Should(Equal(uint64(1)))
+	Ω(psv("-1").ClampUint64(1, 100, 7)).Should(Equal(uint64(7)))
+
+	Ω(psv("0.5").Float64Between(0, 1)).Should(Equal(0.5))
+	Ω(psv("1.5").Float64Between(0, 1, 0.25)).Should(Equal(0.25))
+	_, err = psv("-0.5").ParseFloat64Between(0, 1)
+	Ω(err).Should(Equal(OutOfRangeErr))
+	_, err = psv("NaN").ParseFloat64Between(0, 1)
+	Ω(err).Should(Equal(OutOfRangeErr))
+	Ω(psv("1.5").ClampFloat64(0, 1)).Should(Equal(float64(1)))
+	Ω(psv("1e400").ClampFloat64(0, 1)).Should(Equal(float64(1)))
+	Ω(psv("NaN").ClampFloat64(0, 1, 0.5)).Should(Equal(0.5))
+	Ω(psv("NaNa").ClampFloat64(0, 1, 0.5)).Should(Equal(0.5))
+}
+
+func TestValueSetSizedNumbers(t *testing.T) {
+	RegisterTestingT(t)
+
+	vs := ValueSetFrom([]string{"1", "-1", "300", "x"})
+	Ω(vs.Ints()).Should(Equal([]int{1, -1, 300, 0}))
+	Ω(vs.Int8s()).Should(Equal([]int8{1, -1, 0, 0}))
+	Ω(vs.Int16s()).Should(Equal([]int16{1, -1, 300, 0}))
+	Ω(vs.Int32s()).Should(Equal([]int32{1, -1, 300, 0}))
+	Ω(vs.Uints()).Should(Equal([]uint{1, 0, 300, 0}))
+	Ω(vs.Uint8s()).Should(Equal([]uint8{1, 0, 0, 0}))
+	Ω(vs.Uint16s()).Should(Equal([]uint16{1, 0, 300, 0}))
+	Ω(vs.Uint32s()).Should(Equal([]uint32{1, 0, 300, 0}))
+	Ω(vs.Float32s()).Should(Equal([]float32{1, -1, 300, 0}))
+}
+
+func TestIntSyntax(t *testing.T) {
+	RegisterTestingT(t)
+
+	defer func(syn IntSyntax) { DefaultIntSyntax = syn }(DefaultIntSyntax)
+
+	var err error
+
+	DefaultIntSyntax = DecimalInts
+	Ω(psv("010").Int64()).Should(Equal(int64(10)))
+	Ω(psv("-010").Int()).Should(Equal(-10))
+	Ω(psv("010").Uint64()).Should(Equal(uint64(10)))
+	_, err = psv("0x10").ParseInt64()
+	Ω(err).ShouldNot(BeNil())
+	Ω(err.Error()).Should(HavePrefix("strconv.ParseInt: parsing "))
+	_, err = psv("0b1").ParseUint8()
+	Ω(err).ShouldNot(BeNil())
+	_, err = psv("1_000").ParseInt()
+	Ω(err).ShouldNot(BeNil())
+	Ω(ValueSetFrom([]string{"010", "0x10"}).Int64s()).Should(Equal([]int64{10, 0}))
+
+	DefaultIntSyntax = BasePrefixInts
+	Ω(psv("010").Int64()).Should(Equal(int64(8)))
+	Ω(psv("0x10").Int32()).Should(Equal(int32(16)))
+	Ω(psv("0o17").Uint()).Should(Equal(uint(15)))
+	Ω(psv("0b101").Uint64()).Should(Equal(uint64(5)))
+	_, err = psv("1_000").ParseInt()
+	Ω(err).ShouldNot(BeNil())
+	_, err = psv("0x1_0").ParseInt()
+	Ω(err).ShouldNot(BeNil())
+
+	DefaultIntSyntax = UnderscoreInts
+	Ω(psv("1_000").Int()).Should(Equal(1000))
+	Ω(psv("-1_000_000").Int64()).Should(Equal(int64(-1000000)))
+	Ω(psv("010").Int()).Should(Equal(10))
+	for _, str := range []string{"_1", "1_", "1__0", "-_1", "0x1_0", "1_a"} {
+		_, err = psv(str).ParseInt64()
+		Ω(err).ShouldNot(BeNil(), str)
+	}
+
+	DefaultIntSyntax = BasePrefixInts | UnderscoreInts
+	Ω(psv("0x_1_0").Int()).Should(Equal(16))
+	Ω(psv("1_000").Uint16()).Should(Equal(uint16(1000)))
+	Ω(psv("010").Int()).Should(Equal(8))
+}
@@ -0,0 +1,183 @@
+package simplequery
+
+// Optional is the result of converting a parameter value that keeps apart a
+// missing parameter, an explicit null, a malformed value and a valid one.
+// PATCH-style handlers can use it to tell "do not change" (not Present) from
+// "set to zero" (Valid with the zero Value).
+type Optional[T any] struct {
+	// Present reports whether the parameter was specified at all.
+	Present bool
+	// Null reports whether the value is one of the null literals given in
+	// OptionalOptions.
+	Null bool
+	// Valid reports whether Value holds the converted value.
+	Valid bool
+	// Raw is the value as given, empty if the parameter is missing.
+	Raw string
+	// Value is the converted value, the zero value of T unless Valid.
+	Value T
+	// Err is the conversion error of a present, non-null value.
+	Err error
+}
+
+// OptionalOptions control how values are converted to Optional results.
+type OptionalOptions struct {
+	// Nulls are the literals that denote an explicit null, e.g. "null" or
+	// "". A value matching one of them is reported as Null and is not
+	// converted. No value is treated as null by default.
+	Nulls []string
+	// Registry provides the parsers, DefaultRegistry is used if nil.
+	Registry *Registry
+}
+
+// OptionalOf converts the value to T with the parsers of the registry, see
+// ParseWith.
+func OptionalOf[T any](s *StringValue, opts ...OptionalOptions) Optional[T] {
+	o := OptionalOptions{}
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	r := o.Registry
+	if r == nil {
+		r = DefaultRegistry
+	}
+	return optionalOf(s, o, func(s *StringValue) (T, error) {
+		return ParseWith[T](r, s)
+	})
+}
+
+// OptionalFunc converts the value to T with parse, which may be any of the
+// StringValue parsing methods, e.g. (*StringValue).ParseGeohash.
+func OptionalFunc[T any](s *StringValue, parse func(*StringValue) (T, error), opts ...OptionalOptions) Optional[T] {
+	o := OptionalOptions{}
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return optionalOf(s, o, parse)
+}
+
+// valueResolver is implemented by the getters that may reject the values of
+// a key, such as Resolver.
+type valueResolver interface {
+	Resolve(key string) (*StringValue, error)
+}
+
+// Lookup returns the first value of key converted to T. If q is a Resolver
+// whose policy rejects the values, e.g. SingleValue on "?limit=1&limit=2",
+// the result is Present with the error of the policy in Err.
+func Lookup[T any](q Getter, key string, opts ...OptionalOptions) Optional[T] {
+	if r, ok := q.(valueResolver); ok {
+		val, err := r.Resolve(key)
+		if err != nil {
+			return Optional[T]{Present: true, Err: err}
+		}
+		return OptionalOf[T](val, opts...)
+	}
+	return OptionalOf[T](q.Get(key), opts...)
+}
+
+func optionalOf[T any](s *StringValue, o OptionalOptions, parse func(*StringValue) (T, error)) Optional[T] {
+	res := Optional[T]{}
+	if s == nil {
+		return res
+	}
+
+	res.Present = true
+	res.Raw = string(*s)
+	for _, null := range o.Nulls {
+		if res.Raw == null {
+			res.Null = true
+			return res
+		}
+	}
+
+	if val, err := parse(s); err != nil {
+		res.Err = err
+	} else {
+		res.Valid = true
+		res.Value = val
+	}
+	return res
+}
+
+// Get returns the value and whether it is valid.
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.Valid
+}
+
+// Or returns the value if it is valid, or def otherwise.
+func (o Optional[T]) Or(def T) T {
+	if !o.Valid {
+		return def
+	}
+	return o.Value
+}
+
+// TriBool is a boolean parameter that may also be unset.
+type TriBool int8
+
+const (
+	BoolUnset TriBool = iota
+	BoolFalse
+	BoolTrue
+)
+
+// IsSet reports whether the parameter was specified.
+func (b TriBool) IsSet() bool {
+	return b != BoolUnset
+}
+
+// Or returns the boolean value, or def if the parameter was not specified.
+func (b TriBool) Or(def bool) bool {
+	if b == BoolUnset {
+		return def
+	}
+	return b == BoolTrue
+}
+
+func (b TriBool) String() string {
+	switch b {
+	case BoolFalse:
+		return "false"
+	case BoolTrue:
+		return "true"
+	}
+	return "unset"
+}
+
+// ParseTriBool tells a missing parameter (BoolUnset), a bare "?flag"
+// (BoolTrue) and a boolean value apart. Unlike ParseBool, a missing
+// parameter is not an error; a value that is not a boolean, e.g.
+// "?flag=garbage", is.
+func (s *StringValue) ParseTriBool() (TriBool, error) {
+	if s == nil {
+		return BoolUnset, nil
+	}
+	if *s == "" {
+		return BoolTrue, nil
+	}
+	val, err := s.ParseBool()
+	if err != nil {
+		return BoolUnset, err
+	}
+	if val {
+		return BoolTrue, nil
+	}
+	return BoolFalse, nil
+}
+
+// TriBool is like ParseTriBool but returns the default value for an invalid
+// value. The default is BoolTrue, following the semantics of Bool where a
+// present parameter counts as true.
+func (s *StringValue) TriBool(def ...TriBool) TriBool {
+	defVal := BoolTrue
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseTriBool(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
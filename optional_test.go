@@ -0,0 +1,125 @@
+package simplequery
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestOptionalOf(t *testing.T) {
+	RegisterTestingT(t)
+
+	var res Optional[int64]
+
+	res = OptionalOf[int64](nil)
+	Ω(res).Should(Equal(Optional[int64]{}))
+	Ω(res.Or(5)).Should(Equal(int64(5)))
+
+	res = OptionalOf[int64](psv("0"))
+	Ω(res.Present).Should(BeTrue())
+	Ω(res.Valid).Should(BeTrue())
+	Ω(res.Null).Should(BeFalse())
+	Ω(res.Raw).Should(Equal("0"))
+	Ω(res.Err).Should(BeNil())
+	Ω(res.Or(5)).Should(Equal(int64(0)))
+	val, ok := res.Get()
+	Ω(val).Should(Equal(int64(0)))
+	Ω(ok).Should(BeTrue())
+
+	res = OptionalOf[int64](psv("x"))
+	Ω(res.Present).Should(BeTrue())
+	Ω(res.Valid).Should(BeFalse())
+	Ω(res.Raw).Should(Equal("x"))
+	Ω(res.Err).ShouldNot(BeNil())
+	Ω(res.Or(5)).Should(Equal(int64(5)))
+
+	// Null literals are only recognized when enabled.
+	res = OptionalOf[int64](psv("null"))
+	Ω(res.Null).Should(BeFalse())
+	Ω(res.Err).ShouldNot(BeNil())
+
+	nulls := OptionalOptions{Nulls: []string{"null", ""}}
+	res = OptionalOf[int64](psv("null"), nulls)
+	Ω(res.Present).Should(BeTrue())
+	Ω(res.Null).Should(BeTrue())
+	Ω(res.Valid).Should(BeFalse())
+	Ω(res.Err).Should(BeNil())
+
+	res = OptionalOf[int64](psv(""), nulls)
+	Ω(res.Null).Should(BeTrue())
+
+	res = OptionalOf[int64](nil, nulls)
+	Ω(res.Present).Should(BeFalse())
+	Ω(res.Null).Should(BeFalse())
+
+	r := NewRegistry()
+	Register(r, func(str string) (int64, error) { return int64(len(str)), nil }, nil)
+	res = OptionalOf[int64](psv("abc"), OptionalOptions{Registry: r})
+	Ω(res.Value).Should(Equal(int64(3)))
+}
+
+func TestOptionalFunc(t *testing.T) {
+	RegisterTestingT(t)
+
+	res := OptionalFunc(psv("s"), (*StringValue).ParseGeohash)
+	Ω(res.Valid).Should(BeTrue())
+	Ω(res.Value).Should(Equal(BBox{0, 0, 45, 45}))
+
+	res = OptionalFunc(psv("a"), (*StringValue).ParseGeohash)
+	Ω(res.Valid).Should(BeFalse())
+	Ω(res.Err).ShouldNot(BeNil())
+}
+
+func TestLookup(t *testing.T) {
+	RegisterTestingT(t)
+
+	q := FromQuery(map[string][]string{
+		"limit": {"10", "20"},
+		"name":  {"null"},
+	})
+
+	Ω(Lookup[int](q, "limit").Value).Should(Equal(10))
+	Ω(Lookup[int](q, "offset").Present).Should(BeFalse())
+	Ω(Lookup[string](q, "name").Value).Should(Equal("null"))
+	Ω(Lookup[string](q, "name", OptionalOptions{Nulls: []string{"null"}}).Null).Should(BeTrue())
+}
+
+func TestStringValueTriBool(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω((*StringValue)(nil).TriBool()).Should(Equal(BoolUnset))
+	Ω(psv("").TriBool()).Should(Equal(BoolTrue))
+	Ω(psv("x").TriBool()).Should(Equal(BoolTrue))
+	Ω(psv("on").TriBool()).Should(Equal(BoolTrue))
+	Ω(psv("false").TriBool()).Should(Equal(BoolFalse))
+	Ω(psv("0").TriBool()).Should(Equal(BoolFalse))
+	Ω(psv("x").TriBool(BoolFalse)).Should(Equal(BoolFalse))
+	Ω((*StringValue)(nil).TriBool(BoolTrue)).Should(Equal(BoolUnset))
+
+	var b TriBool
+	var err error
+
+	b, err = (*StringValue)(nil).ParseTriBool()
+	Ω(err).Should(BeNil())
+	Ω(b).Should(Equal(BoolUnset))
+
+	b, err = psv("").ParseTriBool()
+	Ω(err).Should(BeNil())
+	Ω(b).Should(Equal(BoolTrue))
+
+	b, err = psv("off").ParseTriBool()
+	Ω(err).Should(BeNil())
+	Ω(b).Should(Equal(BoolFalse))
+
+	b, err = psv("garbage").ParseTriBool()
+	Ω(err).ShouldNot(BeNil())
+	Ω(b).Should(Equal(BoolUnset))
+
+	Ω(BoolUnset.IsSet()).Should(BeFalse())
+	Ω(BoolFalse.IsSet()).Should(BeTrue())
+	Ω(BoolUnset.Or(true)).Should(BeTrue())
+	Ω(BoolFalse.Or(true)).Should(BeFalse())
+	Ω(BoolTrue.Or(false)).Should(BeTrue())
+	Ω(BoolUnset.String()).Should(Equal("unset"))
+	Ω(BoolTrue.String()).Should(Equal("true"))
+}
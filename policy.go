@@ -0,0 +1,151 @@
+package simplequery
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	DuplicateValueErr = errors.New("The parameter was specified more than once")
+)
+
+// Policy reduces the values of a repeated parameter, e.g.
+// "?limit=10&limit=1000", to a single value. It returns nil if vs is empty.
+type Policy func(vs ValueSet) (*StringValue, error)
+
+// FirstValue picks the first value, the way Q.Get does.
+func FirstValue(vs ValueSet) (*StringValue, error) {
+	if len(vs) == 0 {
+		return nil, nil
+	}
+	return &vs[0], nil
+}
+
+// LastValue picks the last value.
+func LastValue(vs ValueSet) (*StringValue, error) {
+	if len(vs) == 0 {
+		return nil, nil
+	}
+	return &vs[len(vs)-1], nil
+}
+
+// SingleValue rejects repeated parameters with DuplicateValueErr.
+func SingleValue(vs ValueSet) (*StringValue, error) {
+	if len(vs) > 1 {
+		return nil, DuplicateValueErr
+	}
+	return FirstValue(vs)
+}
+
+// JoinValues joins all the values with sep, e.g. "?q=a&q=b" becomes "a b"
+// with sep " ".
+func JoinValues(sep string) Policy {
+	return func(vs ValueSet) (*StringValue, error) {
+		if len(vs) == 0 {
+			return nil, nil
+		}
+		res := StringValue(strings.Join(vs.Strings(), sep))
+		return &res, nil
+	}
+}
+
+// UnionValues treats every value as a list separated by sep and returns the
+// union of the lists in the order of appearance, e.g. "?tags=a,b&tags=b,c"
+// becomes "a,b,c" with sep ",".
+func UnionValues(sep string) Policy {
+	return func(vs ValueSet) (*StringValue, error) {
+		if len(vs) == 0 {
+			return nil, nil
+		}
+		seen := map[string]bool{}
+		items := []string{}
+		for i := range vs {
+			for _, item := range strings.Split(string(vs[i]), sep) {
+				if !seen[item] {
+					seen[item] = true
+					items = append(items, item)
+				}
+			}
+		}
+		res := StringValue(strings.Join(items, sep))
+		return &res, nil
+	}
+}
+
+// Resolver applies policies to the repeated parameters of a query. The value
+// it returns can be used with all the StringValue accessors, and Resolver
+// implements Getter, so the generic accessors such as Get[T] and Lookup
+// apply the policies too.
+type Resolver struct {
+	// Q provides the values, e.g. a Q or a TrackedQ.
+	Q Getter
+	// Default is used for the keys without a policy, nil keeps all values.
+	Default Policy
+	// Policies are the policies of individual keys.
+	//
+	// A nil policy keeps all the values of a key: Get returns the first one
+	// and GetAll all of them, e.g. for list parameters such as "?ids=1&ids=2"
+	// in a query that otherwise rejects duplicates.
+	Policies map[string]Policy
+}
+
+// Resolver returns a resolver of q using def for all the keys.
+func (q Q) Resolver(def Policy) *Resolver {
+	return &Resolver{Q: q, Default: def, Policies: map[string]Policy{}}
+}
+
+// Policy sets the policy of keys and returns r. A nil p keeps all the values
+// of keys.
+func (r *Resolver) Policy(p Policy, keys ...string) *Resolver {
+	if r.Policies == nil {
+		r.Policies = map[string]Policy{}
+	}
+	for _, key := range keys {
+		r.Policies[key] = p
+	}
+	return r
+}
+
+// Resolve returns the value of key according to its policy, or nil if the
+// key is missing.
+func (r *Resolver) Resolve(key string) (*StringValue, error) {
+	p := r.policy(key)
+	if p == nil {
+		p = FirstValue
+	}
+
+	val, err := p(r.Q.GetAll(key))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", key, err)
+	}
+	return val, nil
+}
+
+func (r *Resolver) policy(key string) Policy {
+	if p, ok := r.Policies[key]; ok {
+		return p
+	}
+	return r.Default
+}
+
+// Get is like Resolve but returns nil if the policy rejects the values, so
+// the accessors fall back to their default values. Lookup reports such
+// values as present with the error of Resolve.
+func (r *Resolver) Get(key string) *StringValue {
+	val, _ := r.Resolve(key)
+	return val
+}
+
+// GetAll returns all the values of a key with a nil policy. For other keys,
+// it returns the resolved value as a single-value set, or an empty set if
+// the key is missing or the policy rejects its values.
+func (r *Resolver) GetAll(key string) ValueSet {
+	if r.policy(key) == nil {
+		return r.Q.GetAll(key)
+	}
+	if val := r.Get(key); val != nil {
+		return ValueSet{*val}
+	}
+	return ValueSet{}
+}
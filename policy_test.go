@@ -0,0 +1,133 @@
+package simplequery
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestPolicies(t *testing.T) {
+	RegisterTestingT(t)
+
+	var val *StringValue
+	var err error
+
+	vs := ValueSetFrom([]string{"a,b", "b,c", "d"})
+	empty := ValueSet{}
+
+	val, err = FirstValue(vs)
+	Ω(err).Should(BeNil())
+	Ω(val.String()).Should(Equal("a,b"))
+
+	val, err = LastValue(vs)
+	Ω(err).Should(BeNil())
+	Ω(val.String()).Should(Equal("d"))
+
+	_, err = SingleValue(vs)
+	Ω(err).Should(Equal(DuplicateValueErr))
+	val, err = SingleValue(vs[1:2])
+	Ω(err).Should(BeNil())
+	Ω(val.String()).Should(Equal("b,c"))
+
+	val, err = JoinValues(" ")(vs)
+	Ω(err).Should(BeNil())
+	Ω(val.String()).Should(Equal("a,b b,c d"))
+
+	val, err = UnionValues(",")(vs)
+	Ω(err).Should(BeNil())
+	Ω(val.String()).Should(Equal("a,b,c,d"))
+
+	for _, p := range []Policy{FirstValue, LastValue, SingleValue, JoinValues(","), UnionValues(",")} {
+		val, err = p(empty)
+		Ω(err).Should(BeNil())
+		Ω(val).Should(BeNil())
+	}
+}
+
+func TestResolver(t *testing.T) {
+	RegisterTestingT(t)
+
+	var val *StringValue
+	var err error
+
+	q := FromQuery(map[string][]string{
+		"limit":  {"10", "1000"},
+		"offset": {"5", "7"},
+		"tags":   {"a,b", "c"},
+		"sort":   {"name"},
+	})
+
+	r := q.Resolver(nil)
+	Ω(r.Get("limit").Int64()).Should(Equal(int64(10)))
+	Ω(r.Get("missing")).Should(BeNil())
+
+	r = q.Resolver(SingleValue).
+		Policy(LastValue, "offset").
+		Policy(UnionValues(","), "tags")
+
+	val, err = r.Resolve("limit")
+	Ω(val).Should(BeNil())
+	Ω(errors.Is(err, DuplicateValueErr)).Should(BeTrue())
+	Ω(err.Error()).Should(HavePrefix("limit: "))
+	Ω(r.Get("limit").Int64(20)).Should(Equal(int64(20)))
+
+	Ω(r.Get("offset").Int64()).Should(Equal(int64(7)))
+	Ω(r.Get("sort").String()).Should(Equal("name"))
+	Ω(r.Get("tags").List().Strings()).Should(Equal([]string{"a", "b", "c"}))
+	Ω(Parse[int](r.Get("offset"))).Should(Equal(7))
+
+	val, err = r.Resolve("missing")
+	Ω(err).Should(BeNil())
+	Ω(val).Should(BeNil())
+
+	r = &Resolver{Q: q}
+	r.Policy(LastValue, "limit")
+	Ω(r.Get("limit").Int64()).Should(Equal(int64(1000)))
+}
+
+func TestResolver_Generic(t *testing.T) {
+	RegisterTestingT(t)
+
+	q := FromQuery(map[string][]string{
+		"limit":  {"10", "1000"},
+		"offset": {"5", "7"},
+		"tags":   {"a,b", "c"},
+	})
+
+	tq := q.Track()
+	r := (&Resolver{Q: tq, Default: SingleValue}).
+		Policy(LastValue, "offset").
+		Policy(UnionValues(","), "tags")
+
+	Ω(Get[int](r, "limit", 20)).Should(Equal(20))
+	limit := Lookup[int](r, "limit")
+	Ω(limit.Present).Should(BeTrue())
+	Ω(errors.Is(limit.Err, DuplicateValueErr)).Should(BeTrue())
+	Ω(Get[int](r, "offset")).Should(Equal(7))
+	Ω(GetAll[int](r, "offset")).Should(Equal([]int{7}))
+	Ω(GetAll[int](r, "limit")).Should(Equal([]int{}))
+	Ω(GetAll[string](r, "tags")).Should(Equal([]string{"a,b,c"}))
+	Ω(Lookup[int](r, "missing").Present).Should(BeFalse())
+
+	// Reads through the resolver are tracked.
+	Ω(tq.Unconsumed()).Should(Equal([]string{}))
+}
+
+func TestResolver_NilPolicy(t *testing.T) {
+	RegisterTestingT(t)
+
+	q := FromQuery(map[string][]string{
+		"limit": {"10", "1000"},
+		"ids":   {"1", "2", "3"},
+	})
+
+	r := (&Resolver{Q: q, Default: SingleValue}).Policy(nil, "ids")
+	Ω(GetAll[int](r, "ids")).Should(Equal([]int{1, 2, 3}))
+	Ω(Get[int](r, "ids")).Should(Equal(1))
+	Ω(GetAll[int](r, "limit")).Should(Equal([]int{}))
+
+	r = &Resolver{Q: q}
+	Ω(GetAll[int](r, "limit")).Should(Equal([]int{10, 1000}))
+	Ω(Get[int](r, "limit")).Should(Equal(10))
+}
@@ -0,0 +1,101 @@
+package simplequery
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// Param is a single key/value pair of a raw query string.
+type Param struct {
+	Key   string
+	Value StringValue
+	// HasValue reports whether the pair contains "=", i.e. tells "?k" from
+	// "?k=".
+	HasValue bool
+	// Index is the position of the pair among all the pairs of the query.
+	Index int
+}
+
+// Params are the pairs of a query string in their original order.
+type Params []Param
+
+// FromRawQuery parses a query string such as URL.RawQuery. Unlike
+// url.ParseQuery it keeps the order of the pairs, and fails on the first
+// malformed pair rather than dropping it. Empty pairs, e.g. in "a=1&&b=2",
+// are skipped.
+func FromRawQuery(raw string) (Params, error) {
+	res := Params{}
+	for raw != "" {
+		var pair string
+		pair, raw, _ = strings.Cut(raw, "&")
+		if pair == "" {
+			continue
+		}
+		if strings.Contains(pair, ";") {
+			return nil, &ParseError{"query", pair, errors.New("invalid semicolon separator")}
+		}
+
+		key, val, hasValue := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(key)
+		if err != nil {
+			return nil, &ParseError{"query", pair, err}
+		}
+		val, err = url.QueryUnescape(val)
+		if err != nil {
+			return nil, &ParseError{"query", pair, err}
+		}
+		res = append(res, Param{
+			Key:      key,
+			Value:    StringValue(val),
+			HasValue: hasValue,
+			Index:    len(res),
+		})
+	}
+	return res, nil
+}
+
+// Keys returns the distinct keys in the order of their first appearance.
+func (p Params) Keys() []string {
+	seen := map[string]bool{}
+	res := []string{}
+	for i := range p {
+		if !seen[p[i].Key] {
+			seen[p[i].Key] = true
+			res = append(res, p[i].Key)
+		}
+	}
+	return res
+}
+
+func (p Params) Has(key string) bool {
+	return p.Get(key) != nil
+}
+
+func (p Params) Get(key string) *StringValue {
+	for i := range p {
+		if p[i].Key == key {
+			return &p[i].Value
+		}
+	}
+	return nil
+}
+
+func (p Params) GetAll(key string) ValueSet {
+	res := ValueSet{}
+	for i := range p {
+		if p[i].Key == key {
+			res = append(res, p[i].Value)
+		}
+	}
+	return res
+}
+
+// Q converts the pairs to Q, keeping the order of the values of every key.
+func (p Params) Q() Q {
+	res := NewQ()
+	for i := range p {
+		res[p[i].Key] = append(res[p[i].Key], p[i].Value)
+	}
+	return res
+}
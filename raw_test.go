@@ -0,0 +1,55 @@
+package simplequery
+
+import (
+	"net/url"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestFromRawQuery(t *testing.T) {
+	RegisterTestingT(t)
+
+	var p Params
+	var err error
+
+	p, err = FromRawQuery("b=2&a=x+y&flag&b=1&empty=&&c%5B%5D=%C3%A9")
+	Ω(err).Should(BeNil())
+	Ω(p).Should(Equal(Params{
+		{Key: "b", Value: "2", HasValue: true, Index: 0},
+		{Key: "a", Value: "x y", HasValue: true, Index: 1},
+		{Key: "flag", Value: "", HasValue: false, Index: 2},
+		{Key: "b", Value: "1", HasValue: true, Index: 3},
+		{Key: "empty", Value: "", HasValue: true, Index: 4},
+		{Key: "c[]", Value: "é", HasValue: true, Index: 5},
+	}))
+
+	Ω(p.Keys()).Should(Equal([]string{"b", "a", "flag", "empty", "c[]"}))
+	Ω(p.Has("flag")).Should(BeTrue())
+	Ω(p.Has("missing")).Should(BeFalse())
+	Ω(p.Get("b").Int64()).Should(Equal(int64(2)))
+	Ω(p.Get("missing")).Should(BeNil())
+	Ω(p.GetAll("b").Strings()).Should(Equal([]string{"2", "1"}))
+	Ω(p.GetAll("missing")).Should(Equal(ValueSet{}))
+
+	vals, _ := url.ParseQuery("b=2&a=x+y&flag&b=1&empty=&&c%5B%5D=%C3%A9")
+	Ω(p.Q()).Should(Equal(FromQuery(vals)))
+
+	p, err = FromRawQuery("")
+	Ω(err).Should(BeNil())
+	Ω(p).Should(Equal(Params{}))
+	Ω(p.Q()).Should(Equal(NewQ()))
+
+	p, err = FromRawQuery("a=1=2")
+	Ω(err).Should(BeNil())
+	Ω(p.Get("a").String()).Should(Equal("1=2"))
+
+	for _, raw := range []string{"a=%zz", "a=1&b%=2", "a=%", "a=1;b=2"} {
+		_, err = FromRawQuery(raw)
+		Ω(err).ShouldNot(BeNil(), raw)
+		Ω(err.(*ParseError).Kind).Should(Equal("query"))
+	}
+
+	_, err = FromRawQuery("ok=1&bad=%g1")
+	Ω(err.(*ParseError).Value).Should(Equal("bad=%g1"))
+}
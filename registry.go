@@ -0,0 +1,214 @@
+package simplequery
+
+import (
+	"encoding"
+	"fmt"
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ParserFunc converts a raw parameter value to a value of the type it is
+// registered for.
+type ParserFunc func(str string) (interface{}, error)
+
+// FormatterFunc converts a value of the type it is registered for back to
+// its parameter representation.
+type FormatterFunc func(val interface{}) (string, error)
+
+// Registry holds the parsers and formatters used to convert parameter values
+// to and from arbitrary types. The types without a registered parser or
+// formatter are handled via encoding.TextUnmarshaler and
+// encoding.TextMarshaler.
+//
+// A Registry is safe for concurrent use.
+type Registry struct {
+	mu         sync.RWMutex
+	parsers    map[reflect.Type]ParserFunc
+	formatters map[reflect.Type]FormatterFunc
+}
+
+// NewRegistry returns a registry with the parsers and formatters for all the
+// types supported by the StringValue accessors. Registering another parser
+// or formatter for one of these types overrides it in this registry only.
+func NewRegistry() *Registry {
+	r := &Registry{
+		parsers:    map[reflect.Type]ParserFunc{},
+		formatters: map[reflect.Type]FormatterFunc{},
+	}
+	registerBuiltins(r)
+	return r
+}
+
+// DefaultRegistry is used by Parse, Get, GetAll, Format and Decode.
+var DefaultRegistry = NewRegistry()
+
+func (r *Registry) RegisterParser(t reflect.Type, fn ParserFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.parsers[t] = fn
+}
+
+func (r *Registry) RegisterFormatter(t reflect.Type, fn FormatterFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.formatters[t] = fn
+}
+
+// Parse converts the value to type t. The result is of type t unless an
+// error is returned.
+func (r *Registry) Parse(t reflect.Type, s *StringValue) (interface{}, error) {
+	if s == nil {
+		return nil, UnspecifiedValueErr
+	}
+
+	r.mu.RLock()
+	fn, ok := r.parsers[t]
+	r.mu.RUnlock()
+	if ok {
+		val, err := fn(string(*s))
+		if err == nil && val != nil && !reflect.TypeOf(val).AssignableTo(t) {
+			return nil, fmt.Errorf("%w: the parser for %v returned %T", WrongTypeErr, t, val)
+		}
+		return val, err
+	}
+
+	// Either t or *t may implement encoding.TextUnmarshaler.
+	ptr := reflect.New(t)
+	if t.Kind() == reflect.Ptr {
+		ptr.Elem().Set(reflect.New(t.Elem()))
+		if u, ok := ptr.Elem().Interface().(encoding.TextUnmarshaler); ok {
+			err := u.UnmarshalText([]byte(*s))
+			return ptr.Elem().Interface(), err
+		}
+	}
+	if u, ok := ptr.Interface().(encoding.TextUnmarshaler); ok {
+		err := u.UnmarshalText([]byte(*s))
+		return ptr.Elem().Interface(), err
+	}
+	return nil, fmt.Errorf("%w: %v", UnsupportedTypeErr, t)
+}
+
+// canParse reports whether Parse supports type t.
+func (r *Registry) canParse(t reflect.Type) bool {
+	r.mu.RLock()
+	_, ok := r.parsers[t]
+	r.mu.RUnlock()
+	if ok || reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	return t.Kind() == reflect.Ptr && t.Implements(textUnmarshalerType)
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// Format converts val to its parameter representation. Nil pointers are
+// rejected with NilValueErr.
+func (r *Registry) Format(val interface{}) (string, error) {
+	t := reflect.TypeOf(val)
+	if t != nil && t.Kind() == reflect.Ptr && reflect.ValueOf(val).IsNil() {
+		return "", fmt.Errorf("%w: %v", NilValueErr, t)
+	}
+
+	r.mu.RLock()
+	fn, ok := r.formatters[t]
+	r.mu.RUnlock()
+	if ok {
+		return fn(val)
+	}
+
+	if m, ok := val.(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return string(text), err
+	}
+	return "", fmt.Errorf("%w: %v", UnsupportedTypeErr, t)
+}
+
+// Register adds a parser and a formatter for type T to the registry. Either
+// of them may be nil to keep the current one.
+func Register[T any](r *Registry, parse func(string) (T, error), format func(T) string) {
+	t := typeOf[T]()
+	if parse != nil {
+		r.RegisterParser(t, func(str string) (interface{}, error) {
+			return parse(str)
+		})
+	}
+	if format != nil {
+		r.RegisterFormatter(t, func(val interface{}) (string, error) {
+			return format(val.(T)), nil
+		})
+	}
+}
+
+func registerBuiltin[T any](r *Registry, parse func(s *StringValue) (T, error), format func(T) string) {
+	t := typeOf[T]()
+	r.parsers[t] = func(str string) (interface{}, error) {
+		s := StringValue(str)
+		return parse(&s)
+	}
+	r.formatters[t] = func(val interface{}) (string, error) {
+		return format(val.(T)), nil
+	}
+}
+
+func formatInt[T int | int8 | int16 | int32 | int64](val T) string {
+	return strconv.FormatInt(int64(val), 10)
+}
+
+func formatUint[T uint | uint8 | uint16 | uint32 | uint64](val T) string {
+	return strconv.FormatUint(uint64(val), 10)
+}
+
+func formatString[T fmt.Stringer](val T) string {
+	return val.String()
+}
+
+func registerBuiltins(r *Registry) {
+	registerBuiltin(r, (*StringValue).ParseString, func(val string) string { return val })
+	registerBuiltin(r, (*StringValue).ParseBool, strconv.FormatBool)
+	registerBuiltin(r, (*StringValue).ParseInt, formatInt[int])
+	registerBuiltin(r, (*StringValue).ParseInt8, formatInt[int8])
+	registerBuiltin(r, (*StringValue).ParseInt16, formatInt[int16])
+	registerBuiltin(r, (*StringValue).ParseInt32, formatInt[int32])
+	registerBuiltin(r, (*StringValue).ParseInt64, formatInt[int64])
+	registerBuiltin(r, (*StringValue).ParseUint, formatUint[uint])
+	registerBuiltin(r, (*StringValue).ParseUint8, formatUint[uint8])
+	registerBuiltin(r, (*StringValue).ParseUint16, formatUint[uint16])
+	registerBuiltin(r, (*StringValue).ParseUint32, formatUint[uint32])
+	registerBuiltin(r, (*StringValue).ParseUint64, formatUint[uint64])
+	registerBuiltin(r, (*StringValue).ParseFloat32, func(val float32) string {
+		return strconv.FormatFloat(float64(val), 'g', -1, 32)
+	})
+	registerBuiltin(r, (*StringValue).ParseFloat64, func(val float64) string {
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	})
+	registerBuiltin(r, (*StringValue).ParseTime, func(val time.Time) string {
+		return val.Format(time.RFC3339Nano)
+	})
+	registerBuiltin(r, func(s *StringValue) (time.Duration, error) {
+		return time.ParseDuration(string(*s))
+	}, formatString[time.Duration])
+	registerBuiltin(r, (*StringValue).ParseDate, formatString[Date])
+	registerBuiltin(r, (*StringValue).ParseTimeOfDay, formatString[TimeOfDay])
+	registerBuiltin(r, (*StringValue).ParseDateRange, formatString[DateRange])
+	registerBuiltin(r, (*StringValue).ParseTimeOfDayRange, formatString[TimeOfDayRange])
+	registerBuiltin(r, (*StringValue).ParseBigInt, formatString[*big.Int])
+	registerBuiltin(r, (*StringValue).ParseBigFloat, func(val *big.Float) string {
+		return val.Text('g', -1)
+	})
+	registerBuiltin(r, (*StringValue).ParseRat, func(val *big.Rat) string {
+		return val.RatString()
+	})
+	registerBuiltin(r, (*StringValue).ParseUUID, formatString[UUID])
+	registerBuiltin(r, (*StringValue).ParseIP, formatString[netip.Addr])
+	registerBuiltin(r, (*StringValue).ParsePrefix, formatString[netip.Prefix])
+	registerBuiltin(r, (*StringValue).ParseMAC, formatString[net.HardwareAddr])
+	registerBuiltin(r, (*StringValue).ParseURL, formatString[*url.URL])
+	registerBuiltin(r, (*StringValue).ParsePoint, formatString[Point])
+	registerBuiltin(r, (*StringValue).ParseBBox, formatString[BBox])
+}
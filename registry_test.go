@@ -0,0 +1,162 @@
+package simplequery
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRegistry_Override(t *testing.T) {
+	RegisterTestingT(t)
+
+	var err error
+
+	r := NewRegistry()
+	Register(r, func(str string) (bool, error) {
+		switch str {
+		case "yes":
+			return true, nil
+		case "no":
+			return false, nil
+		}
+		return false, errors.New("expected yes or no")
+	}, func(val bool) string {
+		if val {
+			return "yes"
+		}
+		return "no"
+	})
+	r.RegisterParser(reflect.TypeOf(time.Time{}), func(str string) (interface{}, error) {
+		return time.Parse("02.01.2006", str)
+	})
+
+	Ω(ParseWith[bool](r, psv("yes"))).Should(BeTrue())
+	Ω(FormatWith(r, false)).Should(Equal("no"))
+	_, err = ParseWith[bool](r, psv("true"))
+	Ω(err).Should(MatchError("expected yes or no"))
+	Ω(ParseWith[time.Time](r, psv("03.02.2016"))).Should(Equal(time.Date(2016, 2, 3, 0, 0, 0, 0, time.UTC)))
+
+	// Other registries keep the built-ins.
+	Ω(Parse[bool](psv("true"))).Should(BeTrue())
+	Ω(Format(false)).Should(Equal("false"))
+	_, err = Parse[bool](psv("yes"))
+	Ω(err).ShouldNot(BeNil())
+	Ω(ParseWith[bool](NewRegistry(), psv("true"))).Should(BeTrue())
+
+	q := FromQuery(map[string][]string{
+		"dry": {"yes", "maybe", "no"},
+	})
+	Ω(GetWith(r, q, "dry", false)).Should(BeTrue())
+	Ω(Get(q, "dry", false)).Should(BeFalse())
+	Ω(GetAllWith[bool](r, q, "dry")).Should(Equal([]bool{true, false, false}))
+}
+
+func TestRegistry_Format(t *testing.T) {
+	RegisterTestingT(t)
+
+	var err error
+
+	Ω(Format("abc")).Should(Equal("abc"))
+	Ω(Format(true)).Should(Equal("true"))
+	Ω(Format(-12)).Should(Equal("-12"))
+	Ω(Format(uint8(255))).Should(Equal("255"))
+	Ω(Format(float32(0.1))).Should(Equal("0.1"))
+	Ω(Format(1.5)).Should(Equal("1.5"))
+	Ω(Format(time.Date(2016, 2, 3, 4, 5, 6, 0, time.UTC))).Should(Equal("2016-02-03T04:05:06Z"))
+	Ω(Format(90 * time.Second)).Should(Equal("1m30s"))
+	Ω(Format(Date{2016, time.February, 29})).Should(Equal("2016-02-29"))
+	Ω(Format(netip.MustParseAddr("192.0.2.1"))).Should(Equal("192.0.2.1"))
+	Ω(Format(big.NewRat(3, 6))).Should(Equal("1/2"))
+	Ω(Format(Point{1, 2})).Should(Equal("1,2"))
+
+	// Types without a formatter fall back to encoding.TextMarshaler.
+	Ω(Format(net.ParseIP("10.0.0.1"))).Should(Equal("10.0.0.1"))
+
+	_, err = Format(struct{}{})
+	Ω(errors.Is(err, UnsupportedTypeErr)).Should(BeTrue())
+
+	// Formatted values parse back to the same value.
+	at := time.Date(2016, 2, 3, 4, 5, 6, 7, time.UTC)
+	str, err := Format(at)
+	Ω(err).Should(BeNil())
+	Ω(Parse[time.Time](psv(str))).Should(Equal(at))
+}
+
+func TestRegistry_ByType(t *testing.T) {
+	RegisterTestingT(t)
+
+	var err error
+
+	r := NewRegistry()
+	rt := reflect.TypeOf(testRegion(""))
+	r.RegisterParser(rt, func(str string) (interface{}, error) {
+		return testRegion("region-" + str), nil
+	})
+	r.RegisterFormatter(rt, func(val interface{}) (string, error) {
+		return string(val.(testRegion))[len("region-"):], nil
+	})
+
+	val, err := r.Parse(rt, psv("eu"))
+	Ω(err).Should(BeNil())
+	Ω(val).Should(Equal(testRegion("region-eu")))
+	Ω(r.Format(testRegion("region-us"))).Should(Equal("us"))
+
+	_, err = r.Parse(rt, nil)
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+
+	// The TextUnmarshaler fallback works by type too.
+	val, err = r.Parse(reflect.TypeOf(testColor{}), psv("ff8000"))
+	Ω(err).Should(BeNil())
+	Ω(val).Should(Equal(testColor{255, 128, 0}))
+
+	_, err = r.Parse(reflect.TypeOf(struct{}{}), psv("x"))
+	Ω(errors.Is(err, UnsupportedTypeErr)).Should(BeTrue())
+}
+
+func TestRegistry_WrongType(t *testing.T) {
+	RegisterTestingT(t)
+
+	r := NewRegistry()
+	r.RegisterParser(reflect.TypeOf(0), func(str string) (interface{}, error) {
+		return int64(1), nil
+	})
+
+	res, err := ParseWith[int](r, psv("1"))
+	Ω(res).Should(Equal(0))
+	Ω(errors.Is(err, WrongTypeErr)).Should(BeTrue())
+
+	_, err = r.Parse(reflect.TypeOf(0), psv("1"))
+	Ω(errors.Is(err, WrongTypeErr)).Should(BeTrue())
+
+	// Values assignable to an interface type are accepted.
+	r.RegisterParser(reflect.TypeOf((*fmt.Stringer)(nil)).Elem(), func(str string) (interface{}, error) {
+		return Date{2016, time.February, 3}, nil
+	})
+	s, err := ParseWith[fmt.Stringer](r, psv("x"))
+	Ω(err).Should(BeNil())
+	Ω(s.String()).Should(Equal("2016-02-03"))
+}
+
+func TestRegistry_FormatNil(t *testing.T) {
+	RegisterTestingT(t)
+
+	var err error
+
+	_, err = Format[*url.URL](nil)
+	Ω(errors.Is(err, NilValueErr)).Should(BeTrue())
+	_, err = Format[*big.Float](nil)
+	Ω(errors.Is(err, NilValueErr)).Should(BeTrue())
+	_, err = Format[*testColor](nil)
+	Ω(errors.Is(err, NilValueErr)).Should(BeTrue())
+
+	_, err = Format[interface{}](nil)
+	Ω(errors.Is(err, UnsupportedTypeErr)).Should(BeTrue())
+}
@@ -189,10 +189,7 @@ func (s *StringValue) Bool(def ...bool) bool {
 }
 
 func (s *StringValue) ParseInt64() (int64, error) {
-	if s == nil {
-		return 0, UnspecifiedValueErr
-	}
-	return strconv.ParseInt(string(*s), 0, 64)
+	return s.parseInt(64)
 }
 
 func (s *StringValue) Int64(def ...int64) int64 {
@@ -209,10 +206,7 @@ func (s *StringValue) Int64(def ...int64) int64 {
 }
 
 func (s *StringValue) ParseUint64() (uint64, error) {
-	if s == nil {
-		return 0, UnspecifiedValueErr
-	}
-	return strconv.ParseUint(string(*s), 0, 64)
+	return s.parseUint(64)
 }
 
 func (s *StringValue) Uint64(def ...uint64) uint64 {
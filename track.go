@@ -0,0 +1,201 @@
+package simplequery
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// TrackedQ records the keys read from a query, so that the parameters no
+// code has looked at, e.g. a misspelled "?limt=10", can be logged or
+// rejected. It implements Getter, so the reads of the generic accessors such
+// as Get, GetAll and Lookup, and of Decode are tracked too; the keys of all
+// the decoded fields are read, so Strict suggests them for the misspelled
+// ones. It is safe for concurrent use.
+type TrackedQ struct {
+	q     Q
+	mu    sync.Mutex
+	read  map[string]bool
+	known map[string]bool
+}
+
+// Track returns a TrackedQ reading from q. Known keys are never reported as
+// unexpected by Strict and are used for its suggestions, which is useful for
+// the optional parameters that are not always read.
+func (q Q) Track(known ...string) *TrackedQ {
+	t := &TrackedQ{q: q, read: map[string]bool{}, known: map[string]bool{}}
+	for _, key := range known {
+		t.known[key] = true
+	}
+	return t
+}
+
+func (t *TrackedQ) Has(key string) bool {
+	t.markRead(key)
+	return t.q.Has(key)
+}
+
+func (t *TrackedQ) Get(key string) *StringValue {
+	t.markRead(key)
+	return t.q.Get(key)
+}
+
+func (t *TrackedQ) GetAll(key string) ValueSet {
+	t.markRead(key)
+	return t.q.GetAll(key)
+}
+
+// Map is like Q.Map and marks the keys it collects as read.
+func (t *TrackedQ) Map(prefix string) map[string]ValueSet {
+	res := t.q.Map(prefix)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for k := range t.q {
+		if _, ok := mapKey(k, prefix); ok {
+			t.read[k] = true
+		}
+	}
+	return res
+}
+
+// List is like Q.List and marks the keys it collects as read.
+func (t *TrackedQ) List(key string) ValueSet {
+	res := t.q.List(key)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for k := range t.q {
+		if _, ok := listIndex(k, key); ok || k == key || k == key+"[]" {
+			t.read[k] = true
+		}
+	}
+	return res
+}
+
+func (t *TrackedQ) markRead(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.read[key] = true
+}
+
+// Unconsumed returns the sorted keys of the query that have not been read.
+func (t *TrackedQ) Unconsumed() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	res := []string{}
+	for key := range t.q {
+		if !t.read[key] {
+			res = append(res, key)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Strict returns an *UnexpectedParamsError if the query has keys that have
+// been neither read nor declared known, or nil otherwise. It is meant to be
+// called once the handler has read all the parameters it supports.
+func (t *TrackedQ) Strict() error {
+	unexpected := []string{}
+	for _, key := range t.Unconsumed() {
+		if !t.known[key] {
+			unexpected = append(unexpected, key)
+		}
+	}
+	if len(unexpected) == 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+	candidates := make([]string, 0, len(t.read)+len(t.known))
+	for key := range t.read {
+		candidates = append(candidates, key)
+	}
+	for key := range t.known {
+		if !t.read[key] {
+			candidates = append(candidates, key)
+		}
+	}
+	t.mu.Unlock()
+	sort.Strings(candidates)
+
+	err := &UnexpectedParamsError{Keys: unexpected, Suggestions: map[string]string{}}
+	for _, key := range unexpected {
+		if s := suggestKey(key, candidates); s != "" {
+			err.Suggestions[key] = s
+		}
+	}
+	return err
+}
+
+// UnexpectedParamsError lists the parameters a handler does not support.
+type UnexpectedParamsError struct {
+	// Keys are the unexpected keys in sorted order.
+	Keys []string
+	// Suggestions map the unexpected keys to similar supported ones.
+	Suggestions map[string]string
+}
+
+func (e *UnexpectedParamsError) Error() string {
+	parts := make([]string, len(e.Keys))
+	for i, key := range e.Keys {
+		parts[i] = fmt.Sprintf("%q", key)
+		if s, ok := e.Suggestions[key]; ok {
+			parts[i] += fmt.Sprintf(" (did you mean %q?)", s)
+		}
+	}
+	return "unexpected parameters: " + strings.Join(parts, ", ")
+}
+
+// suggestKey returns the candidate closest to key, or an empty string if
+// none is close enough to be a likely typo.
+func suggestKey(key string, candidates []string) string {
+	best, bestDist := "", -1
+	for _, c := range candidates {
+		dist := editDistance(strings.ToLower(key), strings.ToLower(c))
+		limit := len(c) / 3
+		if limit < 1 {
+			limit = 1
+		}
+		if dist <= limit && (bestDist < 0 || dist < bestDist) {
+			best, bestDist = c, dist
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b, counting a
+// transposition of adjacent characters as a single edit.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(minInt(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = minInt(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,104 @@
+package simplequery
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestTrackedQ(t *testing.T) {
+	RegisterTestingT(t)
+
+	q := FromQuery(map[string][]string{
+		"limt":   {"10"},
+		"offset": {"5"},
+		"sort":   {"name"},
+		"Debug":  {""},
+		"xyz":    {"1"},
+		"tag":    {"a", "b"},
+	})
+
+	tq := q.Track("debug")
+	Ω(tq.Unconsumed()).Should(Equal([]string{"Debug", "limt", "offset", "sort", "tag", "xyz"}))
+
+	Ω(tq.Get("limit").Int64(20)).Should(Equal(int64(20)))
+	Ω(tq.Get("offset").Int64()).Should(Equal(int64(5)))
+	Ω(tq.Has("sort")).Should(BeTrue())
+	Ω(tq.GetAll("tag").Strings()).Should(Equal([]string{"a", "b"}))
+	Ω(tq.Unconsumed()).Should(Equal([]string{"Debug", "limt", "xyz"}))
+
+	err := tq.Strict()
+	Ω(err).ShouldNot(BeNil())
+	perr := err.(*UnexpectedParamsError)
+	Ω(perr.Keys).Should(Equal([]string{"Debug", "limt", "xyz"}))
+	Ω(perr.Suggestions).Should(Equal(map[string]string{"Debug": "debug", "limt": "limit"}))
+	Ω(err.Error()).Should(Equal(`unexpected parameters: "Debug" (did you mean "debug"?), "limt" (did you mean "limit"?), "xyz"`))
+
+	tq.Has("limt")
+	tq.Has("xyz")
+	err = tq.Strict()
+	Ω(err.(*UnexpectedParamsError).Keys).Should(Equal([]string{"Debug"}))
+
+	// Known keys that are present are not unexpected.
+	tq = FromQuery(map[string][]string{"debug": {"1"}}).Track("debug")
+	Ω(tq.Unconsumed()).Should(Equal([]string{"debug"}))
+	Ω(tq.Strict()).Should(BeNil())
+
+	Ω(NewQ().Track().Strict()).Should(BeNil())
+}
+
+func TestEditDistance(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(editDistance("", "")).Should(Equal(0))
+	Ω(editDistance("", "abc")).Should(Equal(3))
+	Ω(editDistance("limit", "limt")).Should(Equal(1))
+	Ω(editDistance("limit", "limti")).Should(Equal(1))
+	Ω(editDistance("offset", "ofset")).Should(Equal(1))
+	Ω(editDistance("kitten", "sitting")).Should(Equal(3))
+	Ω(editDistance("größe", "grösse")).Should(Equal(2))
+
+	Ω(suggestKey("limt", []string{"limit", "offset"})).Should(Equal("limit"))
+	Ω(suggestKey("id", []string{"ids", "is"})).Should(Equal("ids"))
+	Ω(suggestKey("q", []string{"limit"})).Should(Equal(""))
+	Ω(suggestKey("pagesize", []string{"page_size", "page"})).Should(Equal("page_size"))
+}
+
+func TestTrackedQ_Helpers(t *testing.T) {
+	RegisterTestingT(t)
+
+	q := FromQuery(map[string][]string{
+		"limit":     {"10"},
+		"offset":    {"x"},
+		"at":        {"2016-02-03"},
+		"meta[env]": {"prod"},
+		"meta[]":    {"x"},
+		"env":       {"dev"},
+	})
+
+	tq := q.Track()
+	Ω(Get[int](tq, "limit")).Should(Equal(10))
+	Ω(GetAll[int](tq, "offset")).Should(Equal([]int{0}))
+	Ω(Lookup[Date](tq, "at").Valid).Should(BeTrue())
+	Ω(tq.Map("meta")).Should(Equal(map[string]ValueSet{"env": ValueSetFrom([]string{"prod"})}))
+	Ω(tq.Unconsumed()).Should(Equal([]string{"env", "meta[]"}))
+}
+
+func TestGetter(t *testing.T) {
+	RegisterTestingT(t)
+
+	raw := "limit=10&limit=20"
+	params, _ := FromRawQuery(raw)
+	getters := []Getter{
+		FromQuery(map[string][]string{"limit": {"10", "20"}}),
+		params,
+		View(raw),
+		NewMemoQ(params.Q()),
+		params.Q().Track(),
+	}
+	for _, g := range getters {
+		Ω(Get[int](g, "limit")).Should(Equal(10))
+		Ω(GetAll[int](g, "limit")).Should(Equal([]int{10, 20}))
+		Ω(Lookup[int](g, "missing").Present).Should(BeFalse())
+	}
+}
@@ -0,0 +1,276 @@
+package simplequery
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+type unit struct {
+	suffix string
+	size   uint64
+}
+
+// Byte size units, largest first so that formatting picks the largest unit
+// that fits.
+var (
+	siByteUnits = []unit{
+		{"EB", 1e18}, {"PB", 1e15}, {"TB", 1e12}, {"GB", 1e9}, {"MB", 1e6}, {"kB", 1e3},
+	}
+	iecByteUnits = []unit{
+		{"EiB", 1 << 60}, {"PiB", 1 << 50}, {"TiB", 1 << 40}, {"GiB", 1 << 30}, {"MiB", 1 << 20}, {"KiB", 1 << 10},
+	}
+)
+
+// SI prefixes accepted by ParseSI, largest first. "K" is accepted as an
+// alias for "k" when parsing.
+var siPrefixes = []struct {
+	prefix string
+	exp    int
+}{
+	{"E", 18}, {"P", 15}, {"T", 12}, {"G", 9}, {"M", 6}, {"k", 3},
+}
+
+// ParseBytes parses a byte size such as "512", "10MB", "1.5 GiB" or "64k".
+// SI units (kB, MB, ...) are powers of 1000 and IEC units (KiB, MiB, ...) are
+// powers of 1024; units are case-insensitive and the "B" may be omitted.
+// Fractional sizes are rounded down to a whole number of bytes.
+func ParseBytes(str string) (uint64, error) {
+	num, suffix := splitNumberSuffix(str)
+	size, ok := byteUnitSize(suffix)
+	if !ok || checkDecimalNumber(num, false, false) != nil {
+		return 0, syntaxErr("ParseBytes", str)
+	}
+
+	val, ok := new(big.Rat).SetString(num)
+	if !ok {
+		return 0, syntaxErr("ParseBytes", str)
+	}
+	if val.Sign() < 0 {
+		return 0, rangeErr("ParseBytes", str)
+	}
+	val.Mul(val, new(big.Rat).SetInt(new(big.Int).SetUint64(size)))
+	res := new(big.Int).Quo(val.Num(), val.Denom())
+	if !res.IsUint64() {
+		return math.MaxUint64, rangeErr("ParseBytes", str)
+	}
+	return res.Uint64(), nil
+}
+
+func byteUnitSize(suffix string) (uint64, bool) {
+	suffix = strings.ToLower(suffix)
+	if suffix == "" || suffix == "b" {
+		return 1, true
+	}
+	for _, u := range siByteUnits {
+		name := strings.ToLower(u.suffix)
+		if suffix == name || suffix == name[:1] {
+			return u.size, true
+		}
+	}
+	for _, u := range iecByteUnits {
+		name := strings.ToLower(u.suffix)
+		if suffix == name || suffix == name[:2] {
+			return u.size, true
+		}
+	}
+	return 0, false
+}
+
+// FormatBytes formats a byte size using the largest SI or IEC unit that
+// represents it exactly with at most 3 decimal places, e.g. 1536 is "1.5KiB"
+// in IEC units and "1.536kB" in SI ones. The result is accepted by
+// ParseBytes.
+func FormatBytes(n uint64, iec bool) string {
+	units := siByteUnits
+	if iec {
+		units = iecByteUnits
+	}
+	for _, u := range units {
+		if n < u.size {
+			continue
+		}
+		val := new(big.Rat).SetFrac(new(big.Int).SetUint64(n), new(big.Int).SetUint64(u.size))
+		if str, ok := exactDecimal(val, 3); ok {
+			return str + u.suffix
+		}
+	}
+	return strconv.FormatUint(n, 10) + "B"
+}
+
+// ParseSI parses a number with an optional SI prefix, e.g. "1.5k" is 1500
+// and "2M" is 2000000. The returned error is a range error if the result
+// overflows float64.
+func ParseSI(str string) (float64, error) {
+	num, suffix := splitNumberSuffix(str)
+	exp := 0
+	if suffix != "" {
+		found := false
+		for _, p := range siPrefixes {
+			if suffix == p.prefix || (p.prefix == "k" && suffix == "K") {
+				exp, found = p.exp, true
+				break
+			}
+		}
+		if !found {
+			return 0, syntaxErr("ParseSI", str)
+		}
+	}
+	return parseScaledFloat("ParseSI", str, num, exp)
+}
+
+// FormatSI formats a number using the largest SI prefix not exceeding its
+// magnitude. The result is accepted by ParseSI.
+func FormatSI(val float64) string {
+	for _, p := range siPrefixes {
+		if math.Abs(val) >= math.Pow10(p.exp) && !math.IsInf(val, 0) {
+			return shiftDecimal(val, -p.exp) + p.prefix
+		}
+	}
+	return strconv.FormatFloat(val, 'f', -1, 64)
+}
+
+// ParsePercent parses a percentage such as "75%" or "12.5" and returns the
+// corresponding ratio, e.g. 0.75 and 0.125.
+func ParsePercent(str string) (float64, error) {
+	num := strings.TrimSpace(strings.TrimSuffix(str, "%"))
+	return parseScaledFloat("ParsePercent", str, num, -2)
+}
+
+// FormatPercent formats a ratio as a percentage, e.g. 0.75 is "75%".
+func FormatPercent(ratio float64) string {
+	return shiftDecimal(ratio, 2) + "%"
+}
+
+// parseScaledFloat parses num multiplied by 10^exp. The scaling is done on
+// the decimal representation, so that e.g. "0.07" scaled by 2 is exactly 7.
+func parseScaledFloat(fn, str, num string, exp int) (float64, error) {
+	if checkDecimalNumber(num, false, false) != nil {
+		return 0, syntaxErr(fn, str)
+	}
+	val, err := strconv.ParseFloat(num+"e"+strconv.Itoa(exp), 64)
+	if err != nil {
+		if isRangeErr(err) {
+			return val, rangeErr(fn, str)
+		}
+		return 0, syntaxErr(fn, str)
+	}
+	return val, nil
+}
+
+// shiftDecimal formats val multiplied by 10^exp, shifting the decimal point
+// of the shortest representation of val rather than multiplying floats.
+func shiftDecimal(val float64, exp int) string {
+	if math.IsInf(val, 0) || math.IsNaN(val) {
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	}
+
+	// The shortest representation is "[-]d[.ddd]e±dd".
+	str := strconv.FormatFloat(val, 'e', -1, 64)
+	sign := ""
+	if str[0] == '-' {
+		sign, str = "-", str[1:]
+	}
+	idx := strings.IndexByte(str, 'e')
+	digits := strings.Replace(str[:idx], ".", "", 1)
+	e, _ := strconv.Atoi(str[idx+1:])
+
+	point := e + exp + 1
+	switch {
+	case digits == "0":
+		return "0"
+	case point <= 0:
+		return sign + "0." + strings.Repeat("0", -point) + digits
+	case point >= len(digits):
+		return sign + digits + strings.Repeat("0", point-len(digits))
+	}
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// exactDecimal formats r in decimal notation with up to maxPrec decimal
+// places, reporting false if that is not enough to represent r exactly.
+func exactDecimal(r *big.Rat, maxPrec int) (string, bool) {
+	str := r.FloatString(maxPrec)
+	back, _ := new(big.Rat).SetString(str)
+	if strings.Contains(str, ".") {
+		str = strings.TrimRight(strings.TrimRight(str, "0"), ".")
+	}
+	return str, back.Cmp(r) == 0
+}
+
+// splitNumberSuffix splits str into a leading number and a trailing unit
+// suffix, ignoring the white space around them.
+func splitNumberSuffix(str string) (string, string) {
+	str = strings.TrimSpace(str)
+	idx := strings.LastIndexAny(str, "0123456789.") + 1
+	return strings.TrimSpace(str[:idx]), strings.TrimSpace(str[idx:])
+}
+
+func syntaxErr(fn, str string) error {
+	return &strconv.NumError{Func: fn, Num: str, Err: strconv.ErrSyntax}
+}
+
+func rangeErr(fn, str string) error {
+	return &strconv.NumError{Func: fn, Num: str, Err: strconv.ErrRange}
+}
+
+func (s *StringValue) ParseBytes() (uint64, error) {
+	if s == nil {
+		return 0, UnspecifiedValueErr
+	}
+	return ParseBytes(string(*s))
+}
+
+func (s *StringValue) Bytes(def ...uint64) uint64 {
+	defVal := uint64(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseBytes(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParseSI() (float64, error) {
+	if s == nil {
+		return 0, UnspecifiedValueErr
+	}
+	return ParseSI(string(*s))
+}
+
+func (s *StringValue) SI(def ...float64) float64 {
+	defVal := float64(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParseSI(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
+
+func (s *StringValue) ParsePercent() (float64, error) {
+	if s == nil {
+		return 0, UnspecifiedValueErr
+	}
+	return ParsePercent(string(*s))
+}
+
+func (s *StringValue) Percent(def ...float64) float64 {
+	defVal := float64(0)
+	if len(def) > 0 {
+		defVal = def[0]
+	}
+
+	if val, err := s.ParsePercent(); err != nil {
+		return defVal
+	} else {
+		return val
+	}
+}
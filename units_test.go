@@ -0,0 +1,201 @@
+package simplequery
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseBytes(t *testing.T) {
+	RegisterTestingT(t)
+
+	cases := map[string]uint64{
+		"0":        0,
+		"512":      512,
+		"512B":     512,
+		"10MB":     10000000,
+		"10mb":     10000000,
+		"10 MB":    10000000,
+		"64k":      64000,
+		"64kB":     64000,
+		"64KiB":    65536,
+		"64Ki":     65536,
+		"1.5GiB":   1610612736,
+		"1.5 GB":   1500000000,
+		"0.5B":     0,
+		"1.0001kB": 1000,
+		"16EiB":    0,
+		"15EiB":    15 << 60,
+		"18EB":     18e18,
+		".5k":      500,
+	}
+	for str, exp := range cases {
+		res, err := ParseBytes(str)
+		if str == "16EiB" {
+			Ω(err).ShouldNot(BeNil())
+			Ω(isRangeErr(err)).Should(BeTrue())
+			continue
+		}
+		Ω(err).Should(BeNil(), str)
+		Ω(res).Should(Equal(exp), str)
+	}
+
+	for _, str := range []string{"", "MB", "10XB", "10 M B", "-1MB", "1e3", "0x10", "10MBs"} {
+		_, err := ParseBytes(str)
+		Ω(err).ShouldNot(BeNil(), str)
+		Ω(err.Error()).Should(HavePrefix("strconv.ParseBytes: parsing "))
+	}
+
+	_, err := ParseBytes("-1MB")
+	Ω(isRangeErr(err)).Should(BeTrue())
+
+	_, err = ParseBytes("99999999999999999999")
+	Ω(isRangeErr(err)).Should(BeTrue())
+}
+
+func TestFormatBytes(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(FormatBytes(0, false)).Should(Equal("0B"))
+	Ω(FormatBytes(999, false)).Should(Equal("999B"))
+	Ω(FormatBytes(1000, false)).Should(Equal("1kB"))
+	Ω(FormatBytes(1536, false)).Should(Equal("1.536kB"))
+	Ω(FormatBytes(1536, true)).Should(Equal("1.5KiB"))
+	Ω(FormatBytes(10000000, false)).Should(Equal("10MB"))
+	Ω(FormatBytes(1234567, false)).Should(Equal("1234.567kB"))
+	Ω(FormatBytes(1025, true)).Should(Equal("1025B"))
+	Ω(FormatBytes(math.MaxUint64, true)).Should(Equal("18446744073709551615B"))
+	Ω(FormatBytes(15<<60, true)).Should(Equal("15EiB"))
+
+	for _, n := range []uint64{0, 1, 1000, 1024, 1536, 1234567, 10 << 30, math.MaxUint64} {
+		for _, iec := range []bool{false, true} {
+			res, err := ParseBytes(FormatBytes(n, iec))
+			Ω(err).Should(BeNil())
+			Ω(res).Should(Equal(n))
+		}
+	}
+}
+
+func TestParseSI(t *testing.T) {
+	RegisterTestingT(t)
+
+	cases := map[string]float64{
+		"0":     0,
+		"12":    12,
+		"1.5k":  1500,
+		"1.5K":  1500,
+		"2M":    2e6,
+		"-3G":   -3e9,
+		"0.1T":  1e11,
+		"7P":    7e15,
+		"1E":    1e18,
+		"1.5 k": 1500,
+	}
+	for str, exp := range cases {
+		res, err := ParseSI(str)
+		Ω(err).Should(BeNil(), str)
+		Ω(res).Should(Equal(exp), str)
+	}
+
+	for _, str := range []string{"", "k", "1m", "1kk", "1Ki", "1e3k", "Inf", "NaN"} {
+		_, err := ParseSI(str)
+		Ω(err).ShouldNot(BeNil(), str)
+		Ω(err.Error()).Should(HavePrefix("strconv.ParseSI: parsing "))
+	}
+
+	_, err := ParseSI(strings.Repeat("9", 400) + "E")
+	Ω(isRangeErr(err)).Should(BeTrue())
+}
+
+func TestFormatSI(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(FormatSI(0)).Should(Equal("0"))
+	Ω(FormatSI(12.5)).Should(Equal("12.5"))
+	Ω(FormatSI(1500)).Should(Equal("1.5k"))
+	Ω(FormatSI(-2e6)).Should(Equal("-2M"))
+	Ω(FormatSI(1.25e10)).Should(Equal("12.5G"))
+	Ω(FormatSI(3e21)).Should(Equal("3000E"))
+
+	for _, v := range []float64{0, 0.001, 1, 999, 1000, 1234.5678, -7.5e15} {
+		res, err := ParseSI(FormatSI(v))
+		Ω(err).Should(BeNil())
+		Ω(res).Should(Equal(v))
+	}
+}
+
+func TestParsePercent(t *testing.T) {
+	RegisterTestingT(t)
+
+	cases := map[string]float64{
+		"75%":   0.75,
+		"75":    0.75,
+		"7%":    0.07,
+		"12.5%": 0.125,
+		"-5%":   -0.05,
+		"150 %": 1.5,
+		"0%":    0,
+	}
+	for str, exp := range cases {
+		res, err := ParsePercent(str)
+		Ω(err).Should(BeNil(), str)
+		Ω(res).Should(Equal(exp), str)
+	}
+
+	for _, str := range []string{"", "%", "75%%", "a%", "1e2%"} {
+		_, err := ParsePercent(str)
+		Ω(err).ShouldNot(BeNil(), str)
+		Ω(err.(*strconv.NumError).Func).Should(Equal("ParsePercent"))
+	}
+}
+
+func TestFormatPercent(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(FormatPercent(0.75)).Should(Equal("75%"))
+	Ω(FormatPercent(0.07)).Should(Equal("7%"))
+	Ω(FormatPercent(0.125)).Should(Equal("12.5%"))
+	Ω(FormatPercent(-0.0005)).Should(Equal("-0.05%"))
+	Ω(FormatPercent(12)).Should(Equal("1200%"))
+	Ω(FormatPercent(0)).Should(Equal("0%"))
+
+	for _, v := range []float64{0, 0.07, 0.125, 0.333, 1, 2.5} {
+		res, err := ParsePercent(FormatPercent(v))
+		Ω(err).Should(BeNil())
+		Ω(res).Should(Equal(v))
+	}
+}
+
+func TestStringValueUnits(t *testing.T) {
+	RegisterTestingT(t)
+
+	var v *StringValue
+	var err error
+
+	v = psv("10MB")
+	Ω(v.Bytes()).Should(Equal(uint64(10000000)))
+	Ω(v.SI(-1)).Should(Equal(float64(-1)))
+	Ω(v.Percent(-1)).Should(Equal(float64(-1)))
+
+	v = psv("1.5k")
+	Ω(v.Bytes()).Should(Equal(uint64(1500)))
+	Ω(v.SI()).Should(Equal(float64(1500)))
+
+	v = psv("75%")
+	Ω(v.Percent()).Should(Equal(0.75))
+	Ω(v.Bytes(7)).Should(Equal(uint64(7)))
+
+	v = nil
+	_, err = v.ParseBytes()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	_, err = v.ParseSI()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	_, err = v.ParsePercent()
+	Ω(err).Should(Equal(UnspecifiedValueErr))
+	Ω(v.Bytes(1)).Should(Equal(uint64(1)))
+	Ω(v.SI(1)).Should(Equal(float64(1)))
+	Ω(v.Percent(1)).Should(Equal(float64(1)))
+}
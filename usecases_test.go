@@ -5,7 +5,7 @@ import (
 	"net/url"
 )
 
-func ExampleSimpleUseCase() {
+func Example_simpleUseCase() {
 	urlQ, _ := url.ParseQuery("set")
 	q := FromQuery(urlQ)
 
@@ -17,7 +17,7 @@ func ExampleSimpleUseCase() {
 	// Flag `unset` is false
 }
 
-func ExampleDefaultValues() {
+func Example_defaultValues() {
 	urlQ, _ := url.ParseQuery("name=vasya")
 	q := FromQuery(urlQ)
 
@@ -34,7 +34,7 @@ func ExampleDefaultValues() {
 	// Value of `lastname` is <unknown>
 }
 
-func ExampleParsingValues() {
+func Example_parsingValues() {
 	urlQ, _ := url.ParseQuery("updated_at=21")
 	q := FromQuery(urlQ)
 
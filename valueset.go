@@ -0,0 +1,167 @@
+package simplequery
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// IndexError reports the value of a ValueSet that could not be parsed.
+type IndexError struct {
+	Index int
+	Err   error
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("value #%d: %v", e.Index, e.Err)
+}
+
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+func (s ValueSet) Len() int {
+	return len(s)
+}
+
+func (s ValueSet) Last() *StringValue {
+	return s.Index(len(s) - 1)
+}
+
+func (s ValueSet) Contains(val string) bool {
+	for i := range s {
+		if string(s[i]) == val {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns the values for which fn returns true.
+func (s ValueSet) Filter(fn func(StringValue) bool) ValueSet {
+	res := ValueSet{}
+	for i := range s {
+		if fn(s[i]) {
+			res = append(res, s[i])
+		}
+	}
+	return res
+}
+
+// Map returns the results of fn applied to every value.
+func (s ValueSet) Map(fn func(StringValue) StringValue) ValueSet {
+	res := make(ValueSet, len(s))
+	for i := range s {
+		res[i] = fn(s[i])
+	}
+	return res
+}
+
+// Unique returns the values without duplicates, keeping the first
+// occurrence of each.
+func (s ValueSet) Unique() ValueSet {
+	seen := make(map[StringValue]bool, len(s))
+	return s.Filter(func(v StringValue) bool {
+		if seen[v] {
+			return false
+		}
+		seen[v] = true
+		return true
+	})
+}
+
+// Sorted returns a sorted copy of the values.
+func (s ValueSet) Sorted() ValueSet {
+	res := append(ValueSet{}, s...)
+	sort.Slice(res, func(i, j int) bool {
+		return res[i] < res[j]
+	})
+	return res
+}
+
+func (s ValueSet) Join(sep string) string {
+	return strings.Join(s.Strings(), sep)
+}
+
+// Union returns the unique values of both sets, the values of s first.
+func (s ValueSet) Union(other ValueSet) ValueSet {
+	return append(append(ValueSet{}, s...), other...).Unique()
+}
+
+// Intersect returns the unique values of s that are also in other.
+func (s ValueSet) Intersect(other ValueSet) ValueSet {
+	in := other.set()
+	return s.Filter(func(v StringValue) bool {
+		return in[v]
+	}).Unique()
+}
+
+// Difference returns the unique values of s that are not in other.
+func (s ValueSet) Difference(other ValueSet) ValueSet {
+	in := other.set()
+	return s.Filter(func(v StringValue) bool {
+		return !in[v]
+	}).Unique()
+}
+
+func (s ValueSet) set() map[StringValue]bool {
+	res := make(map[StringValue]bool, len(s))
+	for i := range s {
+		res[s[i]] = true
+	}
+	return res
+}
+
+// PluralMode selects how the Parse plural converters, e.g. ParseInt64s,
+// treat values that cannot be parsed.
+type PluralMode int
+
+const (
+	// StrictPlural fails with an *IndexError on the first invalid value.
+	StrictPlural PluralMode = iota
+	// SkipInvalid leaves invalid values out of the result.
+	SkipInvalid
+)
+
+func (s ValueSet) ParseBools(mode ...PluralMode) ([]bool, error) {
+	return parseValues(s, (*StringValue).ParseBool, mode...)
+}
+
+// ParseInt64s is like Int64s but does not replace invalid values with zeros,
+// see PluralMode. By default it fails on the first invalid value.
+func (s ValueSet) ParseInt64s(mode ...PluralMode) ([]int64, error) {
+	return parseValues(s, (*StringValue).ParseInt64, mode...)
+}
+
+func (s ValueSet) ParseUint64s(mode ...PluralMode) ([]uint64, error) {
+	return parseValues(s, (*StringValue).ParseUint64, mode...)
+}
+
+func (s ValueSet) ParseFloat64s(mode ...PluralMode) ([]float64, error) {
+	return parseValues(s, (*StringValue).ParseFloat64, mode...)
+}
+
+func (s ValueSet) ParseTimes(mode ...PluralMode) ([]time.Time, error) {
+	return parseValues(s, (*StringValue).ParseTime, mode...)
+}
+
+func parseValues[T any](s ValueSet, parse func(*StringValue) (T, error), mode ...PluralMode) ([]T, error) {
+	m := StrictPlural
+	if len(mode) > 0 {
+		m = mode[0]
+	}
+
+	res := make([]T, 0, len(s))
+	for i := range s {
+		val, err := parse(&s[i])
+		if err != nil {
+			if m == SkipInvalid {
+				continue
+			}
+			return nil, &IndexError{i, err}
+		}
+		res = append(res, val)
+	}
+	return res, nil
+}
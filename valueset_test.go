@@ -0,0 +1,129 @@
+package simplequery
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestValueSetHelpers(t *testing.T) {
+	RegisterTestingT(t)
+
+	vs := ValueSetFrom([]string{"b", "a", "c", "a"})
+
+	Ω(vs.Len()).Should(Equal(4))
+	Ω(ValueSet{}.Len()).Should(Equal(0))
+	Ω(*vs.Last()).Should(Equal(StringValue("a")))
+	Ω(ValueSet{}.Last()).Should(BeNil())
+
+	Ω(vs.Contains("c")).Should(BeTrue())
+	Ω(vs.Contains("d")).Should(BeFalse())
+
+	Ω(vs.Filter(func(v StringValue) bool { return v != "a" })).Should(Equal(ValueSetFrom([]string{"b", "c"})))
+	Ω(vs.Map(func(v StringValue) StringValue { return StringValue(strings.ToUpper(string(v))) })).
+		Should(Equal(ValueSetFrom([]string{"B", "A", "C", "A"})))
+	Ω(vs.Unique()).Should(Equal(ValueSetFrom([]string{"b", "a", "c"})))
+	Ω(vs.Sorted()).Should(Equal(ValueSetFrom([]string{"a", "a", "b", "c"})))
+	Ω(vs.Join("+")).Should(Equal("b+a+c+a"))
+
+	// The receiver is not modified.
+	Ω(vs).Should(Equal(ValueSetFrom([]string{"b", "a", "c", "a"})))
+
+	other := ValueSetFrom([]string{"c", "d", "d"})
+	Ω(vs.Union(other)).Should(Equal(ValueSetFrom([]string{"b", "a", "c", "d"})))
+	Ω(vs.Intersect(other)).Should(Equal(ValueSetFrom([]string{"c"})))
+	Ω(vs.Difference(other)).Should(Equal(ValueSetFrom([]string{"b", "a"})))
+	Ω(ValueSet{}.Union(ValueSet{})).Should(Equal(ValueSet{}))
+	Ω(ValueSet{}.Intersect(vs)).Should(Equal(ValueSet{}))
+	Ω(vs.Difference(vs)).Should(Equal(ValueSet{}))
+}
+
+func TestValueSetParse(t *testing.T) {
+	RegisterTestingT(t)
+
+	var err error
+
+	Ω(ValueSetFrom([]string{"11", "-2"}).ParseInt64s()).Should(Equal([]int64{11, -2}))
+	Ω(ValueSet{}.ParseInt64s()).Should(Equal([]int64{}))
+
+	_, err = ValueSetFrom([]string{"11", "a"}).ParseInt64s()
+	Ω(err).ShouldNot(BeNil())
+	Ω(err.(*IndexError).Index).Should(Equal(1))
+	Ω(errors.Is(err, strconv.ErrSyntax)).Should(BeTrue())
+	Ω(err.Error()).Should(HavePrefix("value #1: "))
+
+	Ω(ValueSetFrom([]string{"123"}).ParseTimes()).Should(Equal([]time.Time{time.Unix(123, 0).UTC()}))
+
+	_, err = ValueSetFrom([]string{"x", "123"}).ParseTimes()
+	Ω(err.(*IndexError).Index).Should(Equal(0))
+}
+
+func TestValueSetParse_Modes(t *testing.T) {
+	RegisterTestingT(t)
+
+	var err error
+
+	vs := ValueSetFrom([]string{"11", "a", "-3", ""})
+
+	_, err = vs.ParseInt64s(StrictPlural)
+	Ω(err.(*IndexError).Index).Should(Equal(1))
+	Ω(vs.ParseInt64s(SkipInvalid)).Should(Equal([]int64{11, -3}))
+	Ω(vs.ParseInts(SkipInvalid)).Should(Equal([]int{11, -3}))
+	Ω(vs.ParseUint64s(SkipInvalid)).Should(Equal([]uint64{11}))
+	Ω(vs.ParseFloat64s(SkipInvalid)).Should(Equal([]float64{11, -3}))
+	_, err = vs.ParseInt8s()
+	Ω(err.(*IndexError).Index).Should(Equal(1))
+
+	// Unlike Bools, invalid values are not true.
+	bools := ValueSetFrom([]string{"on", "yes", "0"})
+	Ω(bools.Bools()).Should(Equal([]bool{true, true, false}))
+	_, err = bools.ParseBools()
+	Ω(err.(*IndexError).Index).Should(Equal(1))
+	Ω(bools.ParseBools(SkipInvalid)).Should(Equal([]bool{true, false}))
+
+	ids := ValueSetFrom([]string{"192.0.2.1", "x"})
+	_, err = ids.ParseIPs()
+	Ω(err.(*IndexError).Index).Should(Equal(1))
+	Ω(ids.ParseIPs(SkipInvalid)).Should(HaveLen(1))
+	Ω(ids.ParseUUIDs(SkipInvalid)).Should(Equal([]UUID{}))
+
+	dates := ValueSetFrom([]string{"2016-02-03", "2016-02-30"})
+	_, err = dates.ParseDates()
+	Ω(err.(*IndexError).Index).Should(Equal(1))
+	Ω(dates.ParseDates(SkipInvalid)).Should(Equal([]Date{{2016, time.February, 3}}))
+
+	enums := ValueSetFrom([]string{"Open", "pending"})
+	Ω(enums.Enums("open", "closed")).Should(Equal([]string{"open", ""}))
+	_, err = enums.ParseEnums([]string{"open", "closed"})
+	Ω(err.(*IndexError).Index).Should(Equal(1))
+	Ω(enums.ParseEnums([]string{"open", "closed"}, SkipInvalid)).Should(Equal([]string{"open"}))
+
+	Ω(ValueSet{}.ParseFloat32s()).Should(Equal([]float32{}))
+}
+
+func TestValueSetParse_Generic(t *testing.T) {
+	RegisterTestingT(t)
+
+	var err error
+
+	vs := ValueSetFrom([]string{"open", "x", "DONE"})
+
+	Ω(testStatuses.Values(vs)).Should(Equal([]testStatus{testStatusOpen, testStatusUnknown, testStatusClosed}))
+	_, err = testStatuses.ParseValues(vs)
+	Ω(err.(*IndexError).Index).Should(Equal(1))
+	Ω(testStatuses.ParseValues(vs, SkipInvalid)).Should(Equal([]testStatus{testStatusOpen, testStatusClosed}))
+
+	nums := ValueSetFrom([]string{"1", "x", "3"})
+	Ω(GetAll[int](Q{"n": nums}, "n")).Should(Equal([]int{1, 0, 3}))
+	_, err = ParseAll[int](nums)
+	Ω(err.(*IndexError).Index).Should(Equal(1))
+	Ω(ParseAll[int](nums, SkipInvalid)).Should(Equal([]int{1, 3}))
+
+	r := NewRegistry()
+	Register(r, func(str string) (int, error) { return len(str), nil }, nil)
+	Ω(ParseAllWith[int](r, nums)).Should(Equal([]int{1, 1, 1}))
+}
@@ -0,0 +1,130 @@
+package simplequery
+
+import (
+	"net/url"
+	"strings"
+)
+
+// View is a raw query string, e.g. URL.RawQuery, accessed without parsing it
+// upfront. Keys and values are unescaped only when they are looked up, which
+// avoids building a map for handlers that read a few keys. Every lookup scans
+// the whole string, so use FromQuery or FromRawQuery to read many keys.
+//
+// Pairs with malformed escapes or semicolons are ignored, as they are by
+// url.ParseQuery.
+type View string
+
+// Value returns the first value of key and whether it was found. It does not
+// allocate unless the value is escaped.
+func (v View) Value(key string) (StringValue, bool) {
+	for raw := string(v); raw != ""; {
+		var k, val string
+		k, val, raw = nextPair(raw)
+		if !escapedEquals(k, key) {
+			continue
+		}
+		if res, ok := unescapeValue(val); ok {
+			return res, true
+		}
+	}
+	return "", false
+}
+
+func (v View) Has(key string) bool {
+	_, ok := v.Value(key)
+	return ok
+}
+
+func (v View) Get(key string) *StringValue {
+	if val, ok := v.Value(key); ok {
+		return &val
+	}
+	return nil
+}
+
+func (v View) GetAll(key string) ValueSet {
+	res := ValueSet{}
+	for raw := string(v); raw != ""; {
+		var k, val string
+		k, val, raw = nextPair(raw)
+		if !escapedEquals(k, key) {
+			continue
+		}
+		if s, ok := unescapeValue(val); ok {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// Q parses all the pairs into Q.
+func (v View) Q() Q {
+	vals, _ := url.ParseQuery(string(v))
+	return FromQuery(vals)
+}
+
+// nextPair splits off the first pair of raw. The key and the value are empty
+// for pairs that should be ignored.
+func nextPair(raw string) (key, val, rest string) {
+	pair, rest, _ := strings.Cut(raw, "&")
+	if pair == "" || strings.IndexByte(pair, ';') >= 0 {
+		// A malformed escape never equals a key, see escapedEquals.
+		return "%", "", rest
+	}
+	key, val, _ = strings.Cut(pair, "=")
+	return key, val, rest
+}
+
+// escapedEquals reports whether the query-escaped str unescapes to key.
+func escapedEquals(str, key string) bool {
+	if strings.IndexByte(str, '%') < 0 && strings.IndexByte(str, '+') < 0 {
+		return str == key
+	}
+
+	j := 0
+	for i := 0; i < len(str); j++ {
+		c := str[i]
+		switch c {
+		case '+':
+			c = ' '
+			i++
+		case '%':
+			if i+2 >= len(str) || !isHex(str[i+1]) || !isHex(str[i+2]) {
+				return false
+			}
+			c = unhex(str[i+1])<<4 | unhex(str[i+2])
+			i += 3
+		default:
+			i++
+		}
+		if j >= len(key) || key[j] != c {
+			return false
+		}
+	}
+	return j == len(key)
+}
+
+func unescapeValue(str string) (StringValue, bool) {
+	if strings.IndexByte(str, '%') < 0 && strings.IndexByte(str, '+') < 0 {
+		return StringValue(str), true
+	}
+	res, err := url.QueryUnescape(str)
+	if err != nil {
+		return "", false
+	}
+	return StringValue(res), true
+}
+
+func isHex(c byte) bool {
+	return isDigit(c) || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+func unhex(c byte) byte {
+	switch {
+	case isDigit(c):
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	}
+	return c - 'A' + 10
+}
@@ -0,0 +1,101 @@
+package simplequery
+
+import (
+	"net/url"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestView(t *testing.T) {
+	RegisterTestingT(t)
+
+	v := View("b=2&a=x+y&flag&b=1&empty=&&c%5B%5D=%C3%A9&bad=%zz&bad=3&semi=1;x=2&k+1=v")
+
+	Ω(v.Get("b").Int64()).Should(Equal(int64(2)))
+	Ω(v.Get("a").String()).Should(Equal("x y"))
+	Ω(v.Get("c[]").String()).Should(Equal("é"))
+	Ω(v.Get("k 1").String()).Should(Equal("v"))
+	Ω(v.Get("missing")).Should(BeNil())
+	Ω(v.GetAll("b").Strings()).Should(Equal([]string{"2", "1"}))
+	Ω(v.GetAll("missing")).Should(Equal(ValueSet{}))
+
+	Ω(v.Has("flag")).Should(BeTrue())
+	Ω(v.Get("flag").Bool()).Should(BeTrue())
+	Ω(v.Has("empty")).Should(BeTrue())
+	Ω(v.Has("")).Should(BeFalse())
+	Ω(v.Has("c%5B%5D")).Should(BeFalse())
+
+	// Malformed pairs are skipped.
+	Ω(v.Get("bad").String()).Should(Equal("3"))
+	Ω(v.Has("semi")).Should(BeFalse())
+	Ω(v.Has("x")).Should(BeFalse())
+
+	val, ok := v.Value("a")
+	Ω(ok).Should(BeTrue())
+	Ω(val).Should(Equal(StringValue("x y")))
+
+	Ω(View("").Has("")).Should(BeFalse())
+	Ω(View("=1").Get("").String()).Should(Equal("1"))
+	Ω(View("%4").Has("%4")).Should(BeFalse())
+}
+
+func TestView_MatchesFromQuery(t *testing.T) {
+	RegisterTestingT(t)
+
+	raw := "b=2&a=x+y&flag&b=1&empty=&&c%5B%5D=%C3%A9&bad=%zz&bad=3&semi=1;x=2&%41=%42"
+	vals, _ := url.ParseQuery(raw)
+	q := FromQuery(vals)
+	v := View(raw)
+
+	Ω(v.Q()).Should(Equal(q))
+	for k := range q {
+		Ω(v.GetAll(k)).Should(Equal(q.GetAll(k)), k)
+		Ω(v.Get(k)).Should(Equal(q.Get(k)), k)
+	}
+}
+
+func TestView_Allocations(t *testing.T) {
+	RegisterTestingT(t)
+
+	v := View(benchmarkQuery)
+	allocs := testing.AllocsPerRun(100, func() {
+		val, _ := v.Value("limit")
+		_ = val.Int64()
+		_ = v.Has("debug")
+	})
+	Ω(allocs).Should(BeZero())
+}
+
+const benchmarkQuery = "q=red+shoes&category=footwear&sort=price&order=asc" +
+	"&page=3&limit=20&brand=acme&brand=globex&size=42&color=red&utm_source=mail" +
+	"&utm_medium=email&utm_campaign=spring%20sale&debug"
+
+func BenchmarkFromQuery(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		vals, _ := url.ParseQuery(benchmarkQuery)
+		q := FromQuery(vals)
+		_ = q.Get("limit").Int64()
+		_ = q.Has("debug")
+	}
+}
+
+func BenchmarkView(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v := View(benchmarkQuery)
+		_ = v.Get("limit").Int64()
+		_ = v.Has("debug")
+	}
+}
+
+func BenchmarkView_Value(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v := View(benchmarkQuery)
+		val, _ := v.Value("limit")
+		_ = val.Int64()
+		_ = v.Has("debug")
+	}
+}